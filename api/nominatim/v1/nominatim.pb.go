@@ -274,14 +274,20 @@ func (x *Locales) GetCodes() []string {
 // 地址行组件（对齐 Nominatim address）
 type AddressRow struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// 地址组件的人类可读名称
+	// 地址组件的人类可读名称（按 accept_language 本地化）
 	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	// 地址组件类型（country/state/city/road/house_number 等）
 	Type string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
 	// 行政等级，未知时可为 0
 	AdminLevel uint32 `protobuf:"varint,3,opt,name=admin_level,json=adminLevel,proto3" json:"admin_level,omitempty"`
 	// 排序/重要性等级（越小越上层）
-	Rank          uint32 `protobuf:"varint,4,opt,name=rank,proto3" json:"rank,omitempty"`
+	Rank uint32 `protobuf:"varint,4,opt,name=rank,proto3" json:"rank,omitempty"`
+	// 地址对象的 place_id（placex）
+	PlaceId int64 `protobuf:"varint,5,opt,name=place_id,json=placeId,proto3" json:"place_id,omitempty"`
+	// 是否为地址组成部分（isaddress）
+	Isaddress bool `protobuf:"varint,6,opt,name=isaddress,proto3" json:"isaddress,omitempty"`
+	// 地址对象的国家代码（小写）
+	CountryCode   string `protobuf:"bytes,7,opt,name=country_code,json=countryCode,proto3" json:"country_code,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -344,6 +350,27 @@ func (x *AddressRow) GetRank() uint32 {
 	return 0
 }
 
+func (x *AddressRow) GetPlaceId() int64 {
+	if x != nil {
+		return x.PlaceId
+	}
+	return 0
+}
+
+func (x *AddressRow) GetIsaddress() bool {
+	if x != nil {
+		return x.Isaddress
+	}
+	return false
+}
+
+func (x *AddressRow) GetCountryCode() string {
+	if x != nil {
+		return x.CountryCode
+	}
+	return ""
+}
+
 // 搜索结果（尽量对齐 Nominatim 输出）
 type Place struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -377,8 +404,16 @@ type Place struct {
 	AddressRows []*AddressRow `protobuf:"bytes,14,rep,name=address_rows,json=addressRows,proto3" json:"address_rows,omitempty"`
 	// 面要素的 GeoJSON（当 polygon_geojson=true 时返回）
 	PolygonGeojson string `protobuf:"bytes,15,opt,name=polygon_geojson,json=polygonGeojson,proto3" json:"polygon_geojson,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	// 地址对象（addressdetails=true 时返回；键与 Nominatim addresstype 规则一致，如 road/city/postcode/country_code）
+	Address map[string]string `protobuf:"bytes,16,rep,name=address,proto3" json:"address,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// 对象自身的本地化名称
+	Name string `protobuf:"bytes,17,opt,name=name,proto3" json:"name,omitempty"`
+	// 搜索等级（rank_search，对应 jsonv2 的 place_rank）
+	PlaceRank uint32 `protobuf:"varint,18,opt,name=place_rank,json=placeRank,proto3" json:"place_rank,omitempty"`
+	// 地址类型（按 Nominatim get_label_tag 规则，如 road/city/amenity）
+	Addresstype   string `protobuf:"bytes,19,opt,name=addresstype,proto3" json:"addresstype,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Place) Reset() {
@@ -516,6 +551,34 @@ func (x *Place) GetPolygonGeojson() string {
 	return ""
 }
 
+func (x *Place) GetAddress() map[string]string {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *Place) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Place) GetPlaceRank() uint32 {
+	if x != nil {
+		return x.PlaceRank
+	}
+	return 0
+}
+
+func (x *Place) GetAddresstype() string {
+	if x != nil {
+		return x.Addresstype
+	}
+	return ""
+}
+
 // /search 请求（尽量对齐参数集）
 type SearchRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -523,15 +586,15 @@ type SearchRequest struct {
 	Q string `protobuf:"bytes,1,opt,name=q,proto3" json:"q,omitempty"`
 	// 限制国家代码（与 Python 参数名兼容），多个以逗号分隔
 	Countrycodes string `protobuf:"bytes,2,opt,name=countrycodes,proto3" json:"countrycodes,omitempty"`
-	// 返回数量上限（1-50）
+	// 返回数量上限（0 表示使用服务默认值；超过 nominatim.max_limit 时按其截断）
 	Limit uint32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
 	// 分页偏移（>=0）
 	Offset uint32 `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
 	// 是否返回地址行明细（与 Python 参数名兼容）
 	Addressdetails bool `protobuf:"varint,5,opt,name=addressdetails,proto3" json:"addressdetails,omitempty"`
-	// 接受的语言（如："zh,en"），用于本地化显示
+	// 接受的语言（Accept-Language 语法，如 "zh-TW,en;q=0.8"），用于本地化显示；为空时依次回退到 locales、请求头与服务默认语言
 	AcceptLanguage string `protobuf:"bytes,6,opt,name=accept_language,json=acceptLanguage,proto3" json:"accept_language,omitempty"`
-	// 本地化语言优先级（服务内部使用）
+	// 本地化语言优先级（accept_language 为空时使用）
 	Locales *Locales `protobuf:"bytes,7,opt,name=locales,proto3" json:"locales,omitempty"`
 	// 搜索类型过滤（class/type 组合，若支持）
 	Featuretype string `protobuf:"bytes,8,opt,name=featuretype,proto3" json:"featuretype,omitempty"`
@@ -543,7 +606,7 @@ type SearchRequest struct {
 	Bounded bool `protobuf:"varint,11,opt,name=bounded,proto3" json:"bounded,omitempty"`
 	// 是否返回多边形（GeoJSON）
 	PolygonGeojson bool `protobuf:"varint,12,opt,name=polygon_geojson,json=polygonGeojson,proto3" json:"polygon_geojson,omitempty"`
-	// 多边形简化阈值（0-1，越大简化越多）
+	// 多边形简化阈值（>=0，越大简化越多）
 	PolygonThreshold float64 `protobuf:"fixed64,13,opt,name=polygon_threshold,json=polygonThreshold,proto3" json:"polygon_threshold,omitempty"`
 	// 是否返回 extratags
 	Extratags bool `protobuf:"varint,14,opt,name=extratags,proto3" json:"extratags,omitempty"`
@@ -552,7 +615,21 @@ type SearchRequest struct {
 	// 排除的 place_id 列表（用于扩展结果时跳过已有项）
 	ExcludePlaceIds []int64 `protobuf:"varint,16,rep,packed,name=exclude_place_ids,json=excludePlaceIds,proto3" json:"exclude_place_ids,omitempty"`
 	// layer 过滤（逗号分隔：address,poi,railway,natural,manmade）
-	Layer         string `protobuf:"bytes,17,opt,name=layer,proto3" json:"layer,omitempty"`
+	Layer string `protobuf:"bytes,17,opt,name=layer,proto3" json:"layer,omitempty"`
+	// 结构化查询（与 q 互斥）：POI 名称或类型
+	Amenity string `protobuf:"bytes,18,opt,name=amenity,proto3" json:"amenity,omitempty"`
+	// 结构化查询：门牌号与街道名
+	Street string `protobuf:"bytes,19,opt,name=street,proto3" json:"street,omitempty"`
+	// 结构化查询：城市/城镇/村庄
+	City string `protobuf:"bytes,20,opt,name=city,proto3" json:"city,omitempty"`
+	// 结构化查询：县
+	County string `protobuf:"bytes,21,opt,name=county,proto3" json:"county,omitempty"`
+	// 结构化查询：州/省
+	State string `protobuf:"bytes,22,opt,name=state,proto3" json:"state,omitempty"`
+	// 结构化查询：国家
+	Country string `protobuf:"bytes,23,opt,name=country,proto3" json:"country,omitempty"`
+	// 结构化查询：邮政编码
+	Postalcode    string `protobuf:"bytes,24,opt,name=postalcode,proto3" json:"postalcode,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -706,6 +783,55 @@ func (x *SearchRequest) GetLayer() string {
 	return ""
 }
 
+func (x *SearchRequest) GetAmenity() string {
+	if x != nil {
+		return x.Amenity
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetStreet() string {
+	if x != nil {
+		return x.Street
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetCounty() string {
+	if x != nil {
+		return x.County
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetPostalcode() string {
+	if x != nil {
+		return x.Postalcode
+	}
+	return ""
+}
+
 // /search 响应
 type SearchResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -765,17 +891,17 @@ type ReverseRequest struct {
 	Addressdetails bool `protobuf:"varint,4,opt,name=addressdetails,proto3" json:"addressdetails,omitempty"`
 	// 接受的语言（如："zh,en"），用于本地化显示
 	AcceptLanguage string `protobuf:"bytes,5,opt,name=accept_language,json=acceptLanguage,proto3" json:"accept_language,omitempty"`
-	// 本地化语言优先级（服务内部使用）
+	// 本地化语言优先级（accept_language 为空时使用）
 	Locales *Locales `protobuf:"bytes,6,opt,name=locales,proto3" json:"locales,omitempty"`
 	// 是否返回多边形（GeoJSON）
 	PolygonGeojson bool `protobuf:"varint,7,opt,name=polygon_geojson,json=polygonGeojson,proto3" json:"polygon_geojson,omitempty"`
-	// 多边形简化阈值（0-1，越大简化越多）
+	// 多边形简化阈值（>=0，越大简化越多）
 	PolygonThreshold float64 `protobuf:"fixed64,8,opt,name=polygon_threshold,json=polygonThreshold,proto3" json:"polygon_threshold,omitempty"`
 	// 是否返回 extratags
 	Extratags bool `protobuf:"varint,9,opt,name=extratags,proto3" json:"extratags,omitempty"`
 	// 是否返回 namedetails
 	Namedetails bool `protobuf:"varint,10,opt,name=namedetails,proto3" json:"namedetails,omitempty"`
-	// layer 过滤（逗号分隔：address,poi,railway,natural,manmade）
+	// layer 过滤（逗号分隔：address,poi,railway,natural,manmade,postcode；postcode 返回覆盖该点的邮编区域）
 	Layer         string `protobuf:"bytes,11,opt,name=layer,proto3" json:"layer,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -937,13 +1063,13 @@ func (x *ReverseResponse) GetResult() *Place {
 // /lookup 请求
 type LookupRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// OSM 对象 ID 列表（形如：N123、W456、R789）
+	// OSM 对象 ID 列表（形如：N123、W456、R789，最多 50 个）
 	OsmIds []string `protobuf:"bytes,1,rep,name=osm_ids,json=osmIds,proto3" json:"osm_ids,omitempty"`
 	// 是否返回地址行明细
 	Addressdetails bool `protobuf:"varint,2,opt,name=addressdetails,proto3" json:"addressdetails,omitempty"`
 	// 接受的语言（如："zh,en"），用于本地化显示
 	AcceptLanguage string `protobuf:"bytes,3,opt,name=accept_language,json=acceptLanguage,proto3" json:"accept_language,omitempty"`
-	// 本地化语言优先级（服务内部使用）
+	// 本地化语言优先级（accept_language 为空时使用）
 	Locales *Locales `protobuf:"bytes,4,opt,name=locales,proto3" json:"locales,omitempty"`
 	// 是否返回 extratags
 	Extratags bool `protobuf:"varint,5,opt,name=extratags,proto3" json:"extratags,omitempty"`
@@ -951,7 +1077,7 @@ type LookupRequest struct {
 	Namedetails bool `protobuf:"varint,6,opt,name=namedetails,proto3" json:"namedetails,omitempty"`
 	// 是否返回多边形（GeoJSON）
 	PolygonGeojson bool `protobuf:"varint,7,opt,name=polygon_geojson,json=polygonGeojson,proto3" json:"polygon_geojson,omitempty"`
-	// 多边形简化阈值（0-1，越大简化越多）
+	// 多边形简化阈值（>=0，越大简化越多）
 	PolygonThreshold float64 `protobuf:"fixed64,8,opt,name=polygon_threshold,json=polygonThreshold,proto3" json:"polygon_threshold,omitempty"`
 	unknownFields    protoimpl.UnknownFields
 	sizeCache        protoimpl.SizeCache
@@ -1193,12 +1319,24 @@ func (x *StatusResponse) GetUptime() string {
 // /details 请求
 type DetailsRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// OSM 对象 ID（形如：N123/W456/R789）
+	// OSM 对象 ID（形如：N123/W456/R789；配合 osmtype 时可为纯数字，HTTP 亦接受 Nominatim 参数名 osmid）
 	OsmId string `protobuf:"bytes,1,opt,name=osm_id,json=osmId,proto3" json:"osm_id,omitempty"`
-	// 是否返回地址行明细
+	// 是否返回地址层级（address 段，含 isaddress 标记）
 	Addressdetails bool `protobuf:"varint,2,opt,name=addressdetails,proto3" json:"addressdetails,omitempty"`
 	// 接受的语言（如："zh,en"）
 	AcceptLanguage string `protobuf:"bytes,3,opt,name=accept_language,json=acceptLanguage,proto3" json:"accept_language,omitempty"`
+	// 内部 place_id（优先于 OSM 参数）
+	PlaceId int64 `protobuf:"varint,4,opt,name=place_id,json=placeId,proto3" json:"place_id,omitempty"`
+	// OSM 对象类型（N/W/R，与数值 osm_id 配合使用，兼容 Nominatim 参数名）
+	Osmtype string `protobuf:"bytes,5,opt,name=osmtype,proto3" json:"osmtype,omitempty"`
+	// 限定 class（同一 OSM 对象对应多条 placex 时区分）
+	Class string `protobuf:"bytes,6,opt,name=class,proto3" json:"class,omitempty"`
+	// 是否返回 search_name 中的名称/地址关键词
+	Keywords bool `protobuf:"varint,7,opt,name=keywords,proto3" json:"keywords,omitempty"`
+	// 是否返回关联对象（默认返回）
+	Linkedplaces *bool `protobuf:"varint,8,opt,name=linkedplaces,proto3,oneof" json:"linkedplaces,omitempty"`
+	// 是否返回几何的 GeoJSON（否则仅返回质心）
+	PolygonGeojson bool `protobuf:"varint,9,opt,name=polygon_geojson,json=polygonGeojson,proto3" json:"polygon_geojson,omitempty"`
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
@@ -1254,29 +1392,91 @@ func (x *DetailsRequest) GetAcceptLanguage() string {
 	return ""
 }
 
-// /details 响应
-type DetailsResponse struct {
+func (x *DetailsRequest) GetPlaceId() int64 {
+	if x != nil {
+		return x.PlaceId
+	}
+	return 0
+}
+
+func (x *DetailsRequest) GetOsmtype() string {
+	if x != nil {
+		return x.Osmtype
+	}
+	return ""
+}
+
+func (x *DetailsRequest) GetClass() string {
+	if x != nil {
+		return x.Class
+	}
+	return ""
+}
+
+func (x *DetailsRequest) GetKeywords() bool {
+	if x != nil {
+		return x.Keywords
+	}
+	return false
+}
+
+func (x *DetailsRequest) GetLinkedplaces() bool {
+	if x != nil && x.Linkedplaces != nil {
+		return *x.Linkedplaces
+	}
+	return false
+}
+
+func (x *DetailsRequest) GetPolygonGeojson() bool {
+	if x != nil {
+		return x.PolygonGeojson
+	}
+	return false
+}
+
+// 详情中的地址层级/关联对象行
+type DetailsAddressLine struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// 对象详情
-	Result        *Place `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	// 本地化名称
+	Localname string `protobuf:"bytes,1,opt,name=localname,proto3" json:"localname,omitempty"`
+	// 内部 place_id
+	PlaceId int64 `protobuf:"varint,2,opt,name=place_id,json=placeId,proto3" json:"place_id,omitempty"`
+	// OSM 对象类型（N/W/R）
+	OsmType string `protobuf:"bytes,3,opt,name=osm_type,json=osmType,proto3" json:"osm_type,omitempty"`
+	// OSM 对象数值 ID
+	OsmId int64 `protobuf:"varint,4,opt,name=osm_id,json=osmId,proto3" json:"osm_id,omitempty"`
+	// 地址类型标签（如 city/state）
+	PlaceType string `protobuf:"bytes,5,opt,name=place_type,json=placeType,proto3" json:"place_type,omitempty"`
+	// OSM 类别（class）
+	Class string `protobuf:"bytes,6,opt,name=class,proto3" json:"class,omitempty"`
+	// OSM 类型（type）
+	Type string `protobuf:"bytes,7,opt,name=type,proto3" json:"type,omitempty"`
+	// 行政等级（无则 15）
+	AdminLevel uint32 `protobuf:"varint,8,opt,name=admin_level,json=adminLevel,proto3" json:"admin_level,omitempty"`
+	// 地址等级
+	RankAddress uint32 `protobuf:"varint,9,opt,name=rank_address,json=rankAddress,proto3" json:"rank_address,omitempty"`
+	// 与对象的距离（度）
+	Distance float64 `protobuf:"fixed64,10,opt,name=distance,proto3" json:"distance,omitempty"`
+	// 是否为地址组成部分
+	Isaddress     bool `protobuf:"varint,11,opt,name=isaddress,proto3" json:"isaddress,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DetailsResponse) Reset() {
-	*x = DetailsResponse{}
+func (x *DetailsAddressLine) Reset() {
+	*x = DetailsAddressLine{}
 	mi := &file_nominatim_v1_nominatim_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DetailsResponse) String() string {
+func (x *DetailsAddressLine) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DetailsResponse) ProtoMessage() {}
+func (*DetailsAddressLine) ProtoMessage() {}
 
-func (x *DetailsResponse) ProtoReflect() protoreflect.Message {
+func (x *DetailsAddressLine) ProtoReflect() protoreflect.Message {
 	mi := &file_nominatim_v1_nominatim_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1288,41 +1488,113 @@ func (x *DetailsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DetailsResponse.ProtoReflect.Descriptor instead.
-func (*DetailsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use DetailsAddressLine.ProtoReflect.Descriptor instead.
+func (*DetailsAddressLine) Descriptor() ([]byte, []int) {
 	return file_nominatim_v1_nominatim_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *DetailsResponse) GetResult() *Place {
+func (x *DetailsAddressLine) GetLocalname() string {
 	if x != nil {
-		return x.Result
+		return x.Localname
 	}
-	return nil
+	return ""
 }
 
-// /deletable 响应（占位）
-type DeletableResponse struct {
+func (x *DetailsAddressLine) GetPlaceId() int64 {
+	if x != nil {
+		return x.PlaceId
+	}
+	return 0
+}
+
+func (x *DetailsAddressLine) GetOsmType() string {
+	if x != nil {
+		return x.OsmType
+	}
+	return ""
+}
+
+func (x *DetailsAddressLine) GetOsmId() int64 {
+	if x != nil {
+		return x.OsmId
+	}
+	return 0
+}
+
+func (x *DetailsAddressLine) GetPlaceType() string {
+	if x != nil {
+		return x.PlaceType
+	}
+	return ""
+}
+
+func (x *DetailsAddressLine) GetClass() string {
+	if x != nil {
+		return x.Class
+	}
+	return ""
+}
+
+func (x *DetailsAddressLine) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *DetailsAddressLine) GetAdminLevel() uint32 {
+	if x != nil {
+		return x.AdminLevel
+	}
+	return 0
+}
+
+func (x *DetailsAddressLine) GetRankAddress() uint32 {
+	if x != nil {
+		return x.RankAddress
+	}
+	return 0
+}
+
+func (x *DetailsAddressLine) GetDistance() float64 {
+	if x != nil {
+		return x.Distance
+	}
+	return 0
+}
+
+func (x *DetailsAddressLine) GetIsaddress() bool {
+	if x != nil {
+		return x.Isaddress
+	}
+	return false
+}
+
+// search_name 中的关键词
+type DetailsKeyword struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// 可删除对象列表示例（返回 place_id 列表）
-	PlaceIds      []int64 `protobuf:"varint,1,rep,packed,name=place_ids,json=placeIds,proto3" json:"place_ids,omitempty"`
+	// word_id
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// 归一化后的词元
+	Token         string `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeletableResponse) Reset() {
-	*x = DeletableResponse{}
+func (x *DetailsKeyword) Reset() {
+	*x = DetailsKeyword{}
 	mi := &file_nominatim_v1_nominatim_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeletableResponse) String() string {
+func (x *DetailsKeyword) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeletableResponse) ProtoMessage() {}
+func (*DetailsKeyword) ProtoMessage() {}
 
-func (x *DeletableResponse) ProtoReflect() protoreflect.Message {
+func (x *DetailsKeyword) ProtoReflect() protoreflect.Message {
 	mi := &file_nominatim_v1_nominatim_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1334,41 +1606,50 @@ func (x *DeletableResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeletableResponse.ProtoReflect.Descriptor instead.
-func (*DeletableResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use DetailsKeyword.ProtoReflect.Descriptor instead.
+func (*DetailsKeyword) Descriptor() ([]byte, []int) {
 	return file_nominatim_v1_nominatim_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *DeletableResponse) GetPlaceIds() []int64 {
+func (x *DetailsKeyword) GetId() int64 {
 	if x != nil {
-		return x.PlaceIds
+		return x.Id
 	}
-	return nil
+	return 0
 }
 
-// /polygons 响应（占位）
-type PolygonsResponse struct {
+func (x *DetailsKeyword) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+// 详情中的关键词（名称向量与地址向量）
+type DetailsKeywords struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// 问题多边形的 place_id 列表
-	PlaceIds      []int64 `protobuf:"varint,1,rep,packed,name=place_ids,json=placeIds,proto3" json:"place_ids,omitempty"`
+	// 名称关键词（name_vector）
+	Name []*DetailsKeyword `protobuf:"bytes,1,rep,name=name,proto3" json:"name,omitempty"`
+	// 地址关键词（nameaddress_vector）
+	Address       []*DetailsKeyword `protobuf:"bytes,2,rep,name=address,proto3" json:"address,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PolygonsResponse) Reset() {
-	*x = PolygonsResponse{}
+func (x *DetailsKeywords) Reset() {
+	*x = DetailsKeywords{}
 	mi := &file_nominatim_v1_nominatim_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PolygonsResponse) String() string {
+func (x *DetailsKeywords) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PolygonsResponse) ProtoMessage() {}
+func (*DetailsKeywords) ProtoMessage() {}
 
-func (x *PolygonsResponse) ProtoReflect() protoreflect.Message {
+func (x *DetailsKeywords) ProtoReflect() protoreflect.Message {
 	mi := &file_nominatim_v1_nominatim_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1380,74 +1661,1110 @@ func (x *PolygonsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PolygonsResponse.ProtoReflect.Descriptor instead.
-func (*PolygonsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use DetailsKeywords.ProtoReflect.Descriptor instead.
+func (*DetailsKeywords) Descriptor() ([]byte, []int) {
 	return file_nominatim_v1_nominatim_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *PolygonsResponse) GetPlaceIds() []int64 {
+func (x *DetailsKeywords) GetName() []*DetailsKeyword {
 	if x != nil {
-		return x.PlaceIds
+		return x.Name
 	}
 	return nil
 }
 
-var File_nominatim_v1_nominatim_proto protoreflect.FileDescriptor
+func (x *DetailsKeywords) GetAddress() []*DetailsKeyword {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
 
-const file_nominatim_v1_nominatim_proto_rawDesc = "" +
-	"\n" +
-	"\x1cnominatim/v1/nominatim.proto\x12\fnominatim.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1bbuf/validate/validate.proto\x1a\x1bgoogle/protobuf/empty.proto\"]\n" +
-	"\x05Point\x12)\n" +
-	"\x03lat\x18\x01 \x01(\x01B\x17\xbaH\x14\x12\x12\x19\x00\x00\x00\x00\x00\x80V@)\x00\x00\x00\x00\x00\x80V\xc0R\x03lat\x12)\n" +
-	"\x03lon\x18\x02 \x01(\x01B\x17\xbaH\x14\x12\x12\x19\x00\x00\x00\x00\x00\x80f@)\x00\x00\x00\x00\x00\x80f\xc0R\x03lon\"\xc1\x01\n" +
-	"\aViewBox\x12+\n" +
-	"\x04left\x18\x01 \x01(\x01B\x17\xbaH\x14\x12\x12\x19\x00\x00\x00\x00\x00\x80f@)\x00\x00\x00\x00\x00\x80f\xc0R\x04left\x12)\n" +
-	"\x03top\x18\x02 \x01(\x01B\x17\xbaH\x14\x12\x12\x19\x00\x00\x00\x00\x00\x80V@)\x00\x00\x00\x00\x00\x80V\xc0R\x03top\x12-\n" +
-	"\x05right\x18\x03 \x01(\x01B\x17\xbaH\x14\x12\x12\x19\x00\x00\x00\x00\x00\x80f@)\x00\x00\x00\x00\x00\x80f\xc0R\x05right\x12/\n" +
-	"\x06bottom\x18\x04 \x01(\x01B\x17\xbaH\x14\x12\x12\x19\x00\x00\x00\x00\x00\x80V@)\x00\x00\x00\x00\x00\x80V\xc0R\x06bottom\"a\n" +
-	"\vBoundingBox\x12\x14\n" +
-	"\x05south\x18\x01 \x01(\x01R\x05south\x12\x14\n" +
-	"\x05north\x18\x02 \x01(\x01R\x05north\x12\x12\n" +
-	"\x04west\x18\x03 \x01(\x01R\x04west\x12\x12\n" +
-	"\x04east\x18\x04 \x01(\x01R\x04east\"\x1f\n" +
-	"\aLocales\x12\x14\n" +
-	"\x05codes\x18\x01 \x03(\tR\x05codes\"{\n" +
-	"\n" +
-	"AddressRow\x12\x1b\n" +
-	"\x04name\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x04name\x12\x1b\n" +
-	"\x04type\x18\x02 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x04type\x12\x1f\n" +
-	"\vadmin_level\x18\x03 \x01(\rR\n" +
-	"adminLevel\x12\x12\n" +
-	"\x04rank\x18\x04 \x01(\rR\x04rank\"\xf6\x05\n" +
-	"\x05Place\x12\x19\n" +
-	"\bplace_id\x18\x01 \x01(\x03R\aplaceId\x12\x18\n" +
-	"\alicence\x18\x02 \x01(\tR\alicence\x12\x1e\n" +
-	"\x06osm_id\x18\x03 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x05osmId\x125\n" +
-	"\bosm_type\x18\x04 \x01(\tB\x1a\xbaH\x17r\x15R\x04nodeR\x03wayR\brelationR\aosmType\x12\x1a\n" +
-	"\bcategory\x18\x05 \x01(\tR\bcategory\x12\x12\n" +
-	"\x04type\x18\x06 \x01(\tR\x04type\x12\x1e\n" +
-	"\n" +
-	"importance\x18\a \x01(\x01R\n" +
-	"importance\x12!\n" +
-	"\fdisplay_name\x18\b \x01(\tR\vdisplayName\x12/\n" +
-	"\bcentroid\x18\t \x01(\v2\x13.nominatim.v1.PointR\bcentroid\x12;\n" +
-	"\vboundingbox\x18\n" +
-	" \x01(\v2\x19.nominatim.v1.BoundingBoxR\vboundingbox\x12\x12\n" +
-	"\x04icon\x18\v \x01(\tR\x04icon\x12@\n" +
-	"\textratags\x18\f \x03(\v2\".nominatim.v1.Place.ExtratagsEntryR\textratags\x12F\n" +
-	"\vnamedetails\x18\r \x03(\v2$.nominatim.v1.Place.NamedetailsEntryR\vnamedetails\x12;\n" +
-	"\faddress_rows\x18\x0e \x03(\v2\x18.nominatim.v1.AddressRowR\vaddressRows\x12'\n" +
-	"\x0fpolygon_geojson\x18\x0f \x01(\tR\x0epolygonGeojson\x1a<\n" +
-	"\x0eExtratagsEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a>\n" +
-	"\x10NamedetailsEntry\x12\x10\n" +
+// /details 响应（对齐 Nominatim 详情页）
+type DetailsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 对象基础信息（未找到时为空）
+	Result *Place `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	// 父对象 place_id（通常为街道）
+	ParentPlaceId int64 `protobuf:"varint,2,opt,name=parent_place_id,json=parentPlaceId,proto3" json:"parent_place_id,omitempty"`
+	// 被关联到的对象 place_id（如边界关联到的城市节点）
+	LinkedPlaceId int64 `protobuf:"varint,3,opt,name=linked_place_id,json=linkedPlaceId,proto3" json:"linked_place_id,omitempty"`
+	// 行政等级（无则 15）
+	AdminLevel uint32 `protobuf:"varint,4,opt,name=admin_level,json=adminLevel,proto3" json:"admin_level,omitempty"`
+	// 地址等级
+	RankAddress uint32 `protobuf:"varint,5,opt,name=rank_address,json=rankAddress,proto3" json:"rank_address,omitempty"`
+	// 搜索等级
+	RankSearch uint32 `protobuf:"varint,6,opt,name=rank_search,json=rankSearch,proto3" json:"rank_search,omitempty"`
+	// 最近索引时间（ISO 8601）
+	IndexedDate string `protobuf:"bytes,7,opt,name=indexed_date,json=indexedDate,proto3" json:"indexed_date,omitempty"`
+	// 全部名称标签
+	Names map[string]string `protobuf:"bytes,8,rep,name=names,proto3" json:"names,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// 地址标签（addr:*）
+	Addresstags map[string]string `protobuf:"bytes,9,rep,name=addresstags,proto3" json:"addresstags,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// 门牌号
+	Housenumber string `protobuf:"bytes,10,opt,name=housenumber,proto3" json:"housenumber,omitempty"`
+	// 计算得到的邮编（对象自身或最近的邮编点）
+	CalculatedPostcode string `protobuf:"bytes,11,opt,name=calculated_postcode,json=calculatedPostcode,proto3" json:"calculated_postcode,omitempty"`
+	// 国家代码
+	CountryCode string `protobuf:"bytes,12,opt,name=country_code,json=countryCode,proto3" json:"country_code,omitempty"`
+	// 计算得到的重要性（importance 缺失时按 rank_search 估算）
+	CalculatedImportance float64 `protobuf:"fixed64,13,opt,name=calculated_importance,json=calculatedImportance,proto3" json:"calculated_importance,omitempty"`
+	// 维基百科条目（如 "en:Berlin"）
+	CalculatedWikipedia string `protobuf:"bytes,14,opt,name=calculated_wikipedia,json=calculatedWikipedia,proto3" json:"calculated_wikipedia,omitempty"`
+	// 是否为面要素
+	Isarea bool `protobuf:"varint,15,opt,name=isarea,proto3" json:"isarea,omitempty"`
+	// 完整地址层级（含非地址组成部分，addressdetails=true 时返回）
+	Address []*DetailsAddressLine `protobuf:"bytes,16,rep,name=address,proto3" json:"address,omitempty"`
+	// 关联对象（linkedplaces=true 时返回）
+	LinkedPlaces []*DetailsAddressLine `protobuf:"bytes,17,rep,name=linked_places,json=linkedPlaces,proto3" json:"linked_places,omitempty"`
+	// 关键词（keywords=true 时返回）
+	Keywords *DetailsKeywords `protobuf:"bytes,18,opt,name=keywords,proto3" json:"keywords,omitempty"`
+	// 本地化名称
+	Localname     string `protobuf:"bytes,19,opt,name=localname,proto3" json:"localname,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DetailsResponse) Reset() {
+	*x = DetailsResponse{}
+	mi := &file_nominatim_v1_nominatim_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DetailsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DetailsResponse) ProtoMessage() {}
+
+func (x *DetailsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_nominatim_v1_nominatim_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DetailsResponse.ProtoReflect.Descriptor instead.
+func (*DetailsResponse) Descriptor() ([]byte, []int) {
+	return file_nominatim_v1_nominatim_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *DetailsResponse) GetResult() *Place {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *DetailsResponse) GetParentPlaceId() int64 {
+	if x != nil {
+		return x.ParentPlaceId
+	}
+	return 0
+}
+
+func (x *DetailsResponse) GetLinkedPlaceId() int64 {
+	if x != nil {
+		return x.LinkedPlaceId
+	}
+	return 0
+}
+
+func (x *DetailsResponse) GetAdminLevel() uint32 {
+	if x != nil {
+		return x.AdminLevel
+	}
+	return 0
+}
+
+func (x *DetailsResponse) GetRankAddress() uint32 {
+	if x != nil {
+		return x.RankAddress
+	}
+	return 0
+}
+
+func (x *DetailsResponse) GetRankSearch() uint32 {
+	if x != nil {
+		return x.RankSearch
+	}
+	return 0
+}
+
+func (x *DetailsResponse) GetIndexedDate() string {
+	if x != nil {
+		return x.IndexedDate
+	}
+	return ""
+}
+
+func (x *DetailsResponse) GetNames() map[string]string {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+func (x *DetailsResponse) GetAddresstags() map[string]string {
+	if x != nil {
+		return x.Addresstags
+	}
+	return nil
+}
+
+func (x *DetailsResponse) GetHousenumber() string {
+	if x != nil {
+		return x.Housenumber
+	}
+	return ""
+}
+
+func (x *DetailsResponse) GetCalculatedPostcode() string {
+	if x != nil {
+		return x.CalculatedPostcode
+	}
+	return ""
+}
+
+func (x *DetailsResponse) GetCountryCode() string {
+	if x != nil {
+		return x.CountryCode
+	}
+	return ""
+}
+
+func (x *DetailsResponse) GetCalculatedImportance() float64 {
+	if x != nil {
+		return x.CalculatedImportance
+	}
+	return 0
+}
+
+func (x *DetailsResponse) GetCalculatedWikipedia() string {
+	if x != nil {
+		return x.CalculatedWikipedia
+	}
+	return ""
+}
+
+func (x *DetailsResponse) GetIsarea() bool {
+	if x != nil {
+		return x.Isarea
+	}
+	return false
+}
+
+func (x *DetailsResponse) GetAddress() []*DetailsAddressLine {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *DetailsResponse) GetLinkedPlaces() []*DetailsAddressLine {
+	if x != nil {
+		return x.LinkedPlaces
+	}
+	return nil
+}
+
+func (x *DetailsResponse) GetKeywords() *DetailsKeywords {
+	if x != nil {
+		return x.Keywords
+	}
+	return nil
+}
+
+func (x *DetailsResponse) GetLocalname() string {
+	if x != nil {
+		return x.Localname
+	}
+	return ""
+}
+
+// 待删除对象（import_polygon_delete 中的记录）
+type DeletableObject struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 内部 place_id（placex 中已不存在时为 0）
+	PlaceId int64 `protobuf:"varint,1,opt,name=place_id,json=placeId,proto3" json:"place_id,omitempty"`
+	// 国家代码
+	CountryCode string `protobuf:"bytes,2,opt,name=country_code,json=countryCode,proto3" json:"country_code,omitempty"`
+	// 名称
+	Name string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	// OSM 对象数值 ID
+	OsmId int64 `protobuf:"varint,4,opt,name=osm_id,json=osmId,proto3" json:"osm_id,omitempty"`
+	// OSM 对象类型（N/W/R）
+	OsmType string `protobuf:"bytes,5,opt,name=osm_type,json=osmType,proto3" json:"osm_type,omitempty"`
+	// OSM 类别（class）
+	Class string `protobuf:"bytes,6,opt,name=class,proto3" json:"class,omitempty"`
+	// OSM 类型（type）
+	Type          string `protobuf:"bytes,7,opt,name=type,proto3" json:"type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletableObject) Reset() {
+	*x = DeletableObject{}
+	mi := &file_nominatim_v1_nominatim_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletableObject) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletableObject) ProtoMessage() {}
+
+func (x *DeletableObject) ProtoReflect() protoreflect.Message {
+	mi := &file_nominatim_v1_nominatim_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletableObject.ProtoReflect.Descriptor instead.
+func (*DeletableObject) Descriptor() ([]byte, []int) {
+	return file_nominatim_v1_nominatim_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *DeletableObject) GetPlaceId() int64 {
+	if x != nil {
+		return x.PlaceId
+	}
+	return 0
+}
+
+func (x *DeletableObject) GetCountryCode() string {
+	if x != nil {
+		return x.CountryCode
+	}
+	return ""
+}
+
+func (x *DeletableObject) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DeletableObject) GetOsmId() int64 {
+	if x != nil {
+		return x.OsmId
+	}
+	return 0
+}
+
+func (x *DeletableObject) GetOsmType() string {
+	if x != nil {
+		return x.OsmType
+	}
+	return ""
+}
+
+func (x *DeletableObject) GetClass() string {
+	if x != nil {
+		return x.Class
+	}
+	return ""
+}
+
+func (x *DeletableObject) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+// /deletable 响应
+type DeletableResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 待删除对象的 place_id 列表（兼容旧字段）
+	PlaceIds []int64 `protobuf:"varint,1,rep,packed,name=place_ids,json=placeIds,proto3" json:"place_ids,omitempty"`
+	// 待删除对象（格式：json/html/text）
+	Objects       []*DeletableObject `protobuf:"bytes,2,rep,name=objects,proto3" json:"objects,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletableResponse) Reset() {
+	*x = DeletableResponse{}
+	mi := &file_nominatim_v1_nominatim_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletableResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletableResponse) ProtoMessage() {}
+
+func (x *DeletableResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_nominatim_v1_nominatim_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletableResponse.ProtoReflect.Descriptor instead.
+func (*DeletableResponse) Descriptor() ([]byte, []int) {
+	return file_nominatim_v1_nominatim_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *DeletableResponse) GetPlaceIds() []int64 {
+	if x != nil {
+		return x.PlaceIds
+	}
+	return nil
+}
+
+func (x *DeletableResponse) GetObjects() []*DeletableObject {
+	if x != nil {
+		return x.Objects
+	}
+	return nil
+}
+
+// /polygons 请求（对齐 Nominatim 参数）
+type PolygonsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 仅返回最近 N 天内更新的记录（0 表示不限）
+	Days int32 `protobuf:"varint,1,opt,name=days,proto3" json:"days,omitempty"`
+	// 仅返回面积被缩减的记录（errormessage 以 "Area reduced" 开头）
+	Reduced bool `protobuf:"varint,2,opt,name=reduced,proto3" json:"reduced,omitempty"`
+	// 限定 class
+	Class         string `protobuf:"bytes,3,opt,name=class,proto3" json:"class,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PolygonsRequest) Reset() {
+	*x = PolygonsRequest{}
+	mi := &file_nominatim_v1_nominatim_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PolygonsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PolygonsRequest) ProtoMessage() {}
+
+func (x *PolygonsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nominatim_v1_nominatim_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PolygonsRequest.ProtoReflect.Descriptor instead.
+func (*PolygonsRequest) Descriptor() ([]byte, []int) {
+	return file_nominatim_v1_nominatim_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *PolygonsRequest) GetDays() int32 {
+	if x != nil {
+		return x.Days
+	}
+	return 0
+}
+
+func (x *PolygonsRequest) GetReduced() bool {
+	if x != nil {
+		return x.Reduced
+	}
+	return false
+}
+
+func (x *PolygonsRequest) GetClass() string {
+	if x != nil {
+		return x.Class
+	}
+	return ""
+}
+
+// 导入失败的多边形（import_polygon_error 中的记录）
+type PolygonError struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// OSM 对象类型（N/W/R）
+	OsmType string `protobuf:"bytes,1,opt,name=osm_type,json=osmType,proto3" json:"osm_type,omitempty"`
+	// OSM 对象数值 ID
+	OsmId int64 `protobuf:"varint,2,opt,name=osm_id,json=osmId,proto3" json:"osm_id,omitempty"`
+	// OSM 类别（class）
+	Class string `protobuf:"bytes,3,opt,name=class,proto3" json:"class,omitempty"`
+	// OSM 类型（type）
+	Type string `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	// 名称
+	Name string `protobuf:"bytes,5,opt,name=name,proto3" json:"name,omitempty"`
+	// 国家代码
+	CountryCode string `protobuf:"bytes,6,opt,name=country_code,json=countryCode,proto3" json:"country_code,omitempty"`
+	// 错误信息（如 "Self-intersection"）
+	Errormessage string `protobuf:"bytes,7,opt,name=errormessage,proto3" json:"errormessage,omitempty"`
+	// 更新时间（ISO 8601）
+	Updated string `protobuf:"bytes,8,opt,name=updated,proto3" json:"updated,omitempty"`
+	// 错误位置（无法确定时为空）
+	ErrorLocation *Point `protobuf:"bytes,9,opt,name=error_location,json=errorLocation,proto3" json:"error_location,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PolygonError) Reset() {
+	*x = PolygonError{}
+	mi := &file_nominatim_v1_nominatim_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PolygonError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PolygonError) ProtoMessage() {}
+
+func (x *PolygonError) ProtoReflect() protoreflect.Message {
+	mi := &file_nominatim_v1_nominatim_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PolygonError.ProtoReflect.Descriptor instead.
+func (*PolygonError) Descriptor() ([]byte, []int) {
+	return file_nominatim_v1_nominatim_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *PolygonError) GetOsmType() string {
+	if x != nil {
+		return x.OsmType
+	}
+	return ""
+}
+
+func (x *PolygonError) GetOsmId() int64 {
+	if x != nil {
+		return x.OsmId
+	}
+	return 0
+}
+
+func (x *PolygonError) GetClass() string {
+	if x != nil {
+		return x.Class
+	}
+	return ""
+}
+
+func (x *PolygonError) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *PolygonError) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PolygonError) GetCountryCode() string {
+	if x != nil {
+		return x.CountryCode
+	}
+	return ""
+}
+
+func (x *PolygonError) GetErrormessage() string {
+	if x != nil {
+		return x.Errormessage
+	}
+	return ""
+}
+
+func (x *PolygonError) GetUpdated() string {
+	if x != nil {
+		return x.Updated
+	}
+	return ""
+}
+
+func (x *PolygonError) GetErrorLocation() *Point {
+	if x != nil {
+		return x.ErrorLocation
+	}
+	return nil
+}
+
+// /polygons 响应
+type PolygonsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 问题多边形对应的 place_id 列表（兼容旧字段；对象未导入时不含）
+	PlaceIds []int64 `protobuf:"varint,1,rep,packed,name=place_ids,json=placeIds,proto3" json:"place_ids,omitempty"`
+	// 问题多边形（按更新时间倒序，最多 1000 条）
+	Polygons      []*PolygonError `protobuf:"bytes,2,rep,name=polygons,proto3" json:"polygons,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PolygonsResponse) Reset() {
+	*x = PolygonsResponse{}
+	mi := &file_nominatim_v1_nominatim_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PolygonsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PolygonsResponse) ProtoMessage() {}
+
+func (x *PolygonsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_nominatim_v1_nominatim_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PolygonsResponse.ProtoReflect.Descriptor instead.
+func (*PolygonsResponse) Descriptor() ([]byte, []int) {
+	return file_nominatim_v1_nominatim_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *PolygonsResponse) GetPlaceIds() []int64 {
+	if x != nil {
+		return x.PlaceIds
+	}
+	return nil
+}
+
+func (x *PolygonsResponse) GetPolygons() []*PolygonError {
+	if x != nil {
+		return x.Polygons
+	}
+	return nil
+}
+
+// 批量处理中单项的错误
+type BatchError struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 错误码（与 HTTP 状态码一致）
+	Code int32 `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	// 错误原因（如 BAD_REQUEST）
+	Reason string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	// 错误信息
+	Message       string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchError) Reset() {
+	*x = BatchError{}
+	mi := &file_nominatim_v1_nominatim_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchError) ProtoMessage() {}
+
+func (x *BatchError) ProtoReflect() protoreflect.Message {
+	mi := &file_nominatim_v1_nominatim_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchError.ProtoReflect.Descriptor instead.
+func (*BatchError) Descriptor() ([]byte, []int) {
+	return file_nominatim_v1_nominatim_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *BatchError) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *BatchError) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *BatchError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// /search/batch 请求
+type BatchSearchRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 查询列表（每项参数独立，按输入顺序返回）
+	Queries       []*SearchRequest `protobuf:"bytes,1,rep,name=queries,proto3" json:"queries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchSearchRequest) Reset() {
+	*x = BatchSearchRequest{}
+	mi := &file_nominatim_v1_nominatim_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchSearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchSearchRequest) ProtoMessage() {}
+
+func (x *BatchSearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nominatim_v1_nominatim_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchSearchRequest.ProtoReflect.Descriptor instead.
+func (*BatchSearchRequest) Descriptor() ([]byte, []int) {
+	return file_nominatim_v1_nominatim_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *BatchSearchRequest) GetQueries() []*SearchRequest {
+	if x != nil {
+		return x.Queries
+	}
+	return nil
+}
+
+// 批量搜索的单项结果
+type BatchSearchItem struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 成功时为搜索结果，失败时为错误
+	//
+	// Types that are valid to be assigned to Outcome:
+	//
+	//	*BatchSearchItem_Result
+	//	*BatchSearchItem_Error
+	Outcome       isBatchSearchItem_Outcome `protobuf_oneof:"outcome"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchSearchItem) Reset() {
+	*x = BatchSearchItem{}
+	mi := &file_nominatim_v1_nominatim_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchSearchItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchSearchItem) ProtoMessage() {}
+
+func (x *BatchSearchItem) ProtoReflect() protoreflect.Message {
+	mi := &file_nominatim_v1_nominatim_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchSearchItem.ProtoReflect.Descriptor instead.
+func (*BatchSearchItem) Descriptor() ([]byte, []int) {
+	return file_nominatim_v1_nominatim_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *BatchSearchItem) GetOutcome() isBatchSearchItem_Outcome {
+	if x != nil {
+		return x.Outcome
+	}
+	return nil
+}
+
+func (x *BatchSearchItem) GetResult() *SearchResponse {
+	if x != nil {
+		if x, ok := x.Outcome.(*BatchSearchItem_Result); ok {
+			return x.Result
+		}
+	}
+	return nil
+}
+
+func (x *BatchSearchItem) GetError() *BatchError {
+	if x != nil {
+		if x, ok := x.Outcome.(*BatchSearchItem_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+type isBatchSearchItem_Outcome interface {
+	isBatchSearchItem_Outcome()
+}
+
+type BatchSearchItem_Result struct {
+	Result *SearchResponse `protobuf:"bytes,1,opt,name=result,proto3,oneof"`
+}
+
+type BatchSearchItem_Error struct {
+	Error *BatchError `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*BatchSearchItem_Result) isBatchSearchItem_Outcome() {}
+
+func (*BatchSearchItem_Error) isBatchSearchItem_Outcome() {}
+
+// /search/batch 响应
+type BatchSearchResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 与请求 queries 一一对应
+	Items         []*BatchSearchItem `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchSearchResponse) Reset() {
+	*x = BatchSearchResponse{}
+	mi := &file_nominatim_v1_nominatim_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchSearchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchSearchResponse) ProtoMessage() {}
+
+func (x *BatchSearchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_nominatim_v1_nominatim_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchSearchResponse.ProtoReflect.Descriptor instead.
+func (*BatchSearchResponse) Descriptor() ([]byte, []int) {
+	return file_nominatim_v1_nominatim_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *BatchSearchResponse) GetItems() []*BatchSearchItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+// /reverse/batch 请求
+type BatchReverseRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 查询列表（每项参数独立，按输入顺序返回）
+	Queries       []*ReverseRequest `protobuf:"bytes,1,rep,name=queries,proto3" json:"queries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchReverseRequest) Reset() {
+	*x = BatchReverseRequest{}
+	mi := &file_nominatim_v1_nominatim_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchReverseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchReverseRequest) ProtoMessage() {}
+
+func (x *BatchReverseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nominatim_v1_nominatim_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchReverseRequest.ProtoReflect.Descriptor instead.
+func (*BatchReverseRequest) Descriptor() ([]byte, []int) {
+	return file_nominatim_v1_nominatim_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *BatchReverseRequest) GetQueries() []*ReverseRequest {
+	if x != nil {
+		return x.Queries
+	}
+	return nil
+}
+
+// 批量逆地理的单项结果
+type BatchReverseItem struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 成功时为逆地理结果，失败时为错误
+	//
+	// Types that are valid to be assigned to Outcome:
+	//
+	//	*BatchReverseItem_Result
+	//	*BatchReverseItem_Error
+	Outcome       isBatchReverseItem_Outcome `protobuf_oneof:"outcome"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchReverseItem) Reset() {
+	*x = BatchReverseItem{}
+	mi := &file_nominatim_v1_nominatim_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchReverseItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchReverseItem) ProtoMessage() {}
+
+func (x *BatchReverseItem) ProtoReflect() protoreflect.Message {
+	mi := &file_nominatim_v1_nominatim_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchReverseItem.ProtoReflect.Descriptor instead.
+func (*BatchReverseItem) Descriptor() ([]byte, []int) {
+	return file_nominatim_v1_nominatim_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *BatchReverseItem) GetOutcome() isBatchReverseItem_Outcome {
+	if x != nil {
+		return x.Outcome
+	}
+	return nil
+}
+
+func (x *BatchReverseItem) GetResult() *ReverseResponse {
+	if x != nil {
+		if x, ok := x.Outcome.(*BatchReverseItem_Result); ok {
+			return x.Result
+		}
+	}
+	return nil
+}
+
+func (x *BatchReverseItem) GetError() *BatchError {
+	if x != nil {
+		if x, ok := x.Outcome.(*BatchReverseItem_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+type isBatchReverseItem_Outcome interface {
+	isBatchReverseItem_Outcome()
+}
+
+type BatchReverseItem_Result struct {
+	Result *ReverseResponse `protobuf:"bytes,1,opt,name=result,proto3,oneof"`
+}
+
+type BatchReverseItem_Error struct {
+	Error *BatchError `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*BatchReverseItem_Result) isBatchReverseItem_Outcome() {}
+
+func (*BatchReverseItem_Error) isBatchReverseItem_Outcome() {}
+
+// /reverse/batch 响应
+type BatchReverseResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 与请求 queries 一一对应
+	Items         []*BatchReverseItem `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchReverseResponse) Reset() {
+	*x = BatchReverseResponse{}
+	mi := &file_nominatim_v1_nominatim_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchReverseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchReverseResponse) ProtoMessage() {}
+
+func (x *BatchReverseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_nominatim_v1_nominatim_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchReverseResponse.ProtoReflect.Descriptor instead.
+func (*BatchReverseResponse) Descriptor() ([]byte, []int) {
+	return file_nominatim_v1_nominatim_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *BatchReverseResponse) GetItems() []*BatchReverseItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+var File_nominatim_v1_nominatim_proto protoreflect.FileDescriptor
+
+const file_nominatim_v1_nominatim_proto_rawDesc = "" +
+	"\n" +
+	"\x1cnominatim/v1/nominatim.proto\x12\fnominatim.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1bbuf/validate/validate.proto\x1a\x1bgoogle/protobuf/empty.proto\"]\n" +
+	"\x05Point\x12)\n" +
+	"\x03lat\x18\x01 \x01(\x01B\x17\xbaH\x14\x12\x12\x19\x00\x00\x00\x00\x00\x80V@)\x00\x00\x00\x00\x00\x80V\xc0R\x03lat\x12)\n" +
+	"\x03lon\x18\x02 \x01(\x01B\x17\xbaH\x14\x12\x12\x19\x00\x00\x00\x00\x00\x80f@)\x00\x00\x00\x00\x00\x80f\xc0R\x03lon\"\xc1\x01\n" +
+	"\aViewBox\x12+\n" +
+	"\x04left\x18\x01 \x01(\x01B\x17\xbaH\x14\x12\x12\x19\x00\x00\x00\x00\x00\x80f@)\x00\x00\x00\x00\x00\x80f\xc0R\x04left\x12)\n" +
+	"\x03top\x18\x02 \x01(\x01B\x17\xbaH\x14\x12\x12\x19\x00\x00\x00\x00\x00\x80V@)\x00\x00\x00\x00\x00\x80V\xc0R\x03top\x12-\n" +
+	"\x05right\x18\x03 \x01(\x01B\x17\xbaH\x14\x12\x12\x19\x00\x00\x00\x00\x00\x80f@)\x00\x00\x00\x00\x00\x80f\xc0R\x05right\x12/\n" +
+	"\x06bottom\x18\x04 \x01(\x01B\x17\xbaH\x14\x12\x12\x19\x00\x00\x00\x00\x00\x80V@)\x00\x00\x00\x00\x00\x80V\xc0R\x06bottom\"a\n" +
+	"\vBoundingBox\x12\x14\n" +
+	"\x05south\x18\x01 \x01(\x01R\x05south\x12\x14\n" +
+	"\x05north\x18\x02 \x01(\x01R\x05north\x12\x12\n" +
+	"\x04west\x18\x03 \x01(\x01R\x04west\x12\x12\n" +
+	"\x04east\x18\x04 \x01(\x01R\x04east\"\x1f\n" +
+	"\aLocales\x12\x14\n" +
+	"\x05codes\x18\x01 \x03(\tR\x05codes\"\xd7\x01\n" +
+	"\n" +
+	"AddressRow\x12\x1b\n" +
+	"\x04name\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x04name\x12\x1b\n" +
+	"\x04type\x18\x02 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x04type\x12\x1f\n" +
+	"\vadmin_level\x18\x03 \x01(\rR\n" +
+	"adminLevel\x12\x12\n" +
+	"\x04rank\x18\x04 \x01(\rR\x04rank\x12\x19\n" +
+	"\bplace_id\x18\x05 \x01(\x03R\aplaceId\x12\x1c\n" +
+	"\tisaddress\x18\x06 \x01(\bR\tisaddress\x12!\n" +
+	"\fcountry_code\x18\a \x01(\tR\vcountryCode\"\xc3\a\n" +
+	"\x05Place\x12\x19\n" +
+	"\bplace_id\x18\x01 \x01(\x03R\aplaceId\x12\x18\n" +
+	"\alicence\x18\x02 \x01(\tR\alicence\x12\x1e\n" +
+	"\x06osm_id\x18\x03 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x05osmId\x125\n" +
+	"\bosm_type\x18\x04 \x01(\tB\x1a\xbaH\x17r\x15R\x04nodeR\x03wayR\brelationR\aosmType\x12\x1a\n" +
+	"\bcategory\x18\x05 \x01(\tR\bcategory\x12\x12\n" +
+	"\x04type\x18\x06 \x01(\tR\x04type\x12\x1e\n" +
+	"\n" +
+	"importance\x18\a \x01(\x01R\n" +
+	"importance\x12!\n" +
+	"\fdisplay_name\x18\b \x01(\tR\vdisplayName\x12/\n" +
+	"\bcentroid\x18\t \x01(\v2\x13.nominatim.v1.PointR\bcentroid\x12;\n" +
+	"\vboundingbox\x18\n" +
+	" \x01(\v2\x19.nominatim.v1.BoundingBoxR\vboundingbox\x12\x12\n" +
+	"\x04icon\x18\v \x01(\tR\x04icon\x12@\n" +
+	"\textratags\x18\f \x03(\v2\".nominatim.v1.Place.ExtratagsEntryR\textratags\x12F\n" +
+	"\vnamedetails\x18\r \x03(\v2$.nominatim.v1.Place.NamedetailsEntryR\vnamedetails\x12;\n" +
+	"\faddress_rows\x18\x0e \x03(\v2\x18.nominatim.v1.AddressRowR\vaddressRows\x12'\n" +
+	"\x0fpolygon_geojson\x18\x0f \x01(\tR\x0epolygonGeojson\x12:\n" +
+	"\aaddress\x18\x10 \x03(\v2 .nominatim.v1.Place.AddressEntryR\aaddress\x12\x12\n" +
+	"\x04name\x18\x11 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"place_rank\x18\x12 \x01(\rR\tplaceRank\x12 \n" +
+	"\vaddresstype\x18\x13 \x01(\tR\vaddresstype\x1a<\n" +
+	"\x0eExtratagsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a>\n" +
+	"\x10NamedetailsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xe2\x04\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a:\n" +
+	"\fAddressEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xd7\x06\n" +
 	"\rSearchRequest\x12\f\n" +
-	"\x01q\x18\x01 \x01(\tR\x01q\x12\"\n" +
-	"\fcountrycodes\x18\x02 \x01(\tR\fcountrycodes\x12\x1f\n" +
-	"\x05limit\x18\x03 \x01(\rB\t\xbaH\x06*\x04\x182(\x01R\x05limit\x12\x1f\n" +
+	"\x01q\x18\x01 \x01(\tR\x01q\x12V\n" +
+	"\fcountrycodes\x18\x02 \x01(\tB2\xbaH/\xd8\x01\x01r*2(^\\s*[A-Za-z]{2}(\\s*,\\s*[A-Za-z]{2})*\\s*$R\fcountrycodes\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\rR\x05limit\x12\x1f\n" +
 	"\x06offset\x18\x04 \x01(\rB\a\xbaH\x04*\x02(\x00R\x06offset\x12&\n" +
 	"\x0eaddressdetails\x18\x05 \x01(\bR\x0eaddressdetails\x12'\n" +
 	"\x0faccept_language\x18\x06 \x01(\tR\x0eacceptLanguage\x12/\n" +
@@ -1457,14 +2774,23 @@ const file_nominatim_v1_nominatim_proto_rawDesc = "" +
 	"\aviewbox\x18\n" +
 	" \x01(\v2\x15.nominatim.v1.ViewBoxR\aviewbox\x12\x18\n" +
 	"\abounded\x18\v \x01(\bR\abounded\x12'\n" +
-	"\x0fpolygon_geojson\x18\f \x01(\bR\x0epolygonGeojson\x12+\n" +
-	"\x11polygon_threshold\x18\r \x01(\x01R\x10polygonThreshold\x12\x1c\n" +
+	"\x0fpolygon_geojson\x18\f \x01(\bR\x0epolygonGeojson\x12;\n" +
+	"\x11polygon_threshold\x18\r \x01(\x01B\x0e\xbaH\v\x12\t)\x00\x00\x00\x00\x00\x00\x00\x00R\x10polygonThreshold\x12\x1c\n" +
 	"\textratags\x18\x0e \x01(\bR\textratags\x12 \n" +
-	"\vnamedetails\x18\x0f \x01(\bR\vnamedetails\x12*\n" +
-	"\x11exclude_place_ids\x18\x10 \x03(\x03R\x0fexcludePlaceIds\x12\x14\n" +
-	"\x05layer\x18\x11 \x01(\tR\x05layer\"?\n" +
+	"\vnamedetails\x18\x0f \x01(\bR\vnamedetails\x128\n" +
+	"\x11exclude_place_ids\x18\x10 \x03(\x03B\f\xbaH\t\x92\x01\x06\"\x04\"\x02 \x00R\x0fexcludePlaceIds\x12\x14\n" +
+	"\x05layer\x18\x11 \x01(\tR\x05layer\x12\x18\n" +
+	"\aamenity\x18\x12 \x01(\tR\aamenity\x12\x16\n" +
+	"\x06street\x18\x13 \x01(\tR\x06street\x12\x12\n" +
+	"\x04city\x18\x14 \x01(\tR\x04city\x12\x16\n" +
+	"\x06county\x18\x15 \x01(\tR\x06county\x12\x14\n" +
+	"\x05state\x18\x16 \x01(\tR\x05state\x12\x18\n" +
+	"\acountry\x18\x17 \x01(\tR\acountry\x12\x1e\n" +
+	"\n" +
+	"postalcode\x18\x18 \x01(\tR\n" +
+	"postalcode\"?\n" +
 	"\x0eSearchResponse\x12-\n" +
-	"\aresults\x18\x01 \x03(\v2\x13.nominatim.v1.PlaceR\aresults\"\xb3\x03\n" +
+	"\aresults\x18\x01 \x03(\v2\x13.nominatim.v1.PlaceR\aresults\"\xc3\x03\n" +
 	"\x0eReverseRequest\x12)\n" +
 	"\x03lat\x18\x01 \x01(\x01B\x17\xbaH\x14\x12\x12\x19\x00\x00\x00\x00\x00\x80V@)\x00\x00\x00\x00\x00\x80V\xc0R\x03lat\x12)\n" +
 	"\x03lon\x18\x02 \x01(\x01B\x17\xbaH\x14\x12\x12\x19\x00\x00\x00\x00\x00\x80f@)\x00\x00\x00\x00\x00\x80f\xc0R\x03lon\x12\x1d\n" +
@@ -1472,51 +2798,155 @@ const file_nominatim_v1_nominatim_proto_rawDesc = "" +
 	"\x0eaddressdetails\x18\x04 \x01(\bR\x0eaddressdetails\x12'\n" +
 	"\x0faccept_language\x18\x05 \x01(\tR\x0eacceptLanguage\x12/\n" +
 	"\alocales\x18\x06 \x01(\v2\x15.nominatim.v1.LocalesR\alocales\x12'\n" +
-	"\x0fpolygon_geojson\x18\a \x01(\bR\x0epolygonGeojson\x12+\n" +
-	"\x11polygon_threshold\x18\b \x01(\x01R\x10polygonThreshold\x12\x1c\n" +
+	"\x0fpolygon_geojson\x18\a \x01(\bR\x0epolygonGeojson\x12;\n" +
+	"\x11polygon_threshold\x18\b \x01(\x01B\x0e\xbaH\v\x12\t)\x00\x00\x00\x00\x00\x00\x00\x00R\x10polygonThreshold\x12\x1c\n" +
 	"\textratags\x18\t \x01(\bR\textratags\x12 \n" +
 	"\vnamedetails\x18\n" +
 	" \x01(\bR\vnamedetails\x12\x14\n" +
 	"\x05layer\x18\v \x01(\tR\x05layer\">\n" +
 	"\x0fReverseResponse\x12+\n" +
-	"\x06result\x18\x01 \x01(\v2\x13.nominatim.v1.PlaceR\x06result\"\xca\x02\n" +
-	"\rLookupRequest\x12!\n" +
-	"\aosm_ids\x18\x01 \x03(\tB\b\xbaH\x05\x92\x01\x02\b\x01R\x06osmIds\x12&\n" +
+	"\x06result\x18\x01 \x01(\v2\x13.nominatim.v1.PlaceR\x06result\"\xf2\x02\n" +
+	"\rLookupRequest\x129\n" +
+	"\aosm_ids\x18\x01 \x03(\tB \xbaH\x1d\x92\x01\x1a\b\x01\x102\"\x14r\x122\x10^[NWRnwr][0-9]+$R\x06osmIds\x12&\n" +
 	"\x0eaddressdetails\x18\x02 \x01(\bR\x0eaddressdetails\x12'\n" +
 	"\x0faccept_language\x18\x03 \x01(\tR\x0eacceptLanguage\x12/\n" +
 	"\alocales\x18\x04 \x01(\v2\x15.nominatim.v1.LocalesR\alocales\x12\x1c\n" +
 	"\textratags\x18\x05 \x01(\bR\textratags\x12 \n" +
 	"\vnamedetails\x18\x06 \x01(\bR\vnamedetails\x12'\n" +
-	"\x0fpolygon_geojson\x18\a \x01(\bR\x0epolygonGeojson\x12+\n" +
-	"\x11polygon_threshold\x18\b \x01(\x01R\x10polygonThreshold\"?\n" +
+	"\x0fpolygon_geojson\x18\a \x01(\bR\x0epolygonGeojson\x12;\n" +
+	"\x11polygon_threshold\x18\b \x01(\x01B\x0e\xbaH\v\x12\t)\x00\x00\x00\x00\x00\x00\x00\x00R\x10polygonThreshold\"?\n" +
 	"\x0eLookupResponse\x12-\n" +
 	"\aresults\x18\x01 \x03(\v2\x13.nominatim.v1.PlaceR\aresults\"\x0f\n" +
 	"\rStatusRequest\"_\n" +
 	"\x0eStatusResponse\x12\x18\n" +
 	"\aversion\x18\x01 \x01(\tR\aversion\x12\x1b\n" +
 	"\tdb_status\x18\x02 \x01(\tR\bdbStatus\x12\x16\n" +
-	"\x06uptime\x18\x03 \x01(\tR\x06uptime\"\x8e\x01\n" +
-	"\x0eDetailsRequest\x12+\n" +
-	"\x06osm_id\x18\x01 \x01(\tB\x14\xbaH\x11r\x0f2\r^[NWR][0-9]+$R\x05osmId\x12&\n" +
+	"\x06uptime\x18\x03 \x01(\tR\x06uptime\"\xfe\x02\n" +
+	"\x0eDetailsRequest\x122\n" +
+	"\x06osm_id\x18\x01 \x01(\tB\x1b\xbaH\x18\xd8\x01\x01r\x132\x11^[NWRnwr]?[0-9]+$R\x05osmId\x12&\n" +
 	"\x0eaddressdetails\x18\x02 \x01(\bR\x0eaddressdetails\x12'\n" +
-	"\x0faccept_language\x18\x03 \x01(\tR\x0eacceptLanguage\">\n" +
+	"\x0faccept_language\x18\x03 \x01(\tR\x0eacceptLanguage\x12\"\n" +
+	"\bplace_id\x18\x04 \x01(\x03B\a\xbaH\x04\"\x02(\x00R\aplaceId\x12.\n" +
+	"\aosmtype\x18\x05 \x01(\tB\x14\xbaH\x11\xd8\x01\x01r\f2\n" +
+	"^[NWRnwr]$R\aosmtype\x12\x14\n" +
+	"\x05class\x18\x06 \x01(\tR\x05class\x12\x1a\n" +
+	"\bkeywords\x18\a \x01(\bR\bkeywords\x12'\n" +
+	"\flinkedplaces\x18\b \x01(\bH\x00R\flinkedplaces\x88\x01\x01\x12'\n" +
+	"\x0fpolygon_geojson\x18\t \x01(\bR\x0epolygonGeojsonB\x0f\n" +
+	"\r_linkedplaces\"\xc6\x02\n" +
+	"\x12DetailsAddressLine\x12\x1c\n" +
+	"\tlocalname\x18\x01 \x01(\tR\tlocalname\x12\x19\n" +
+	"\bplace_id\x18\x02 \x01(\x03R\aplaceId\x12\x19\n" +
+	"\bosm_type\x18\x03 \x01(\tR\aosmType\x12\x15\n" +
+	"\x06osm_id\x18\x04 \x01(\x03R\x05osmId\x12\x1d\n" +
+	"\n" +
+	"place_type\x18\x05 \x01(\tR\tplaceType\x12\x14\n" +
+	"\x05class\x18\x06 \x01(\tR\x05class\x12\x12\n" +
+	"\x04type\x18\a \x01(\tR\x04type\x12\x1f\n" +
+	"\vadmin_level\x18\b \x01(\rR\n" +
+	"adminLevel\x12!\n" +
+	"\frank_address\x18\t \x01(\rR\vrankAddress\x12\x1a\n" +
+	"\bdistance\x18\n" +
+	" \x01(\x01R\bdistance\x12\x1c\n" +
+	"\tisaddress\x18\v \x01(\bR\tisaddress\"6\n" +
+	"\x0eDetailsKeyword\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\"{\n" +
+	"\x0fDetailsKeywords\x120\n" +
+	"\x04name\x18\x01 \x03(\v2\x1c.nominatim.v1.DetailsKeywordR\x04name\x126\n" +
+	"\aaddress\x18\x02 \x03(\v2\x1c.nominatim.v1.DetailsKeywordR\aaddress\"\xf4\a\n" +
 	"\x0fDetailsResponse\x12+\n" +
-	"\x06result\x18\x01 \x01(\v2\x13.nominatim.v1.PlaceR\x06result\"0\n" +
+	"\x06result\x18\x01 \x01(\v2\x13.nominatim.v1.PlaceR\x06result\x12&\n" +
+	"\x0fparent_place_id\x18\x02 \x01(\x03R\rparentPlaceId\x12&\n" +
+	"\x0flinked_place_id\x18\x03 \x01(\x03R\rlinkedPlaceId\x12\x1f\n" +
+	"\vadmin_level\x18\x04 \x01(\rR\n" +
+	"adminLevel\x12!\n" +
+	"\frank_address\x18\x05 \x01(\rR\vrankAddress\x12\x1f\n" +
+	"\vrank_search\x18\x06 \x01(\rR\n" +
+	"rankSearch\x12!\n" +
+	"\findexed_date\x18\a \x01(\tR\vindexedDate\x12>\n" +
+	"\x05names\x18\b \x03(\v2(.nominatim.v1.DetailsResponse.NamesEntryR\x05names\x12P\n" +
+	"\vaddresstags\x18\t \x03(\v2..nominatim.v1.DetailsResponse.AddresstagsEntryR\vaddresstags\x12 \n" +
+	"\vhousenumber\x18\n" +
+	" \x01(\tR\vhousenumber\x12/\n" +
+	"\x13calculated_postcode\x18\v \x01(\tR\x12calculatedPostcode\x12!\n" +
+	"\fcountry_code\x18\f \x01(\tR\vcountryCode\x123\n" +
+	"\x15calculated_importance\x18\r \x01(\x01R\x14calculatedImportance\x121\n" +
+	"\x14calculated_wikipedia\x18\x0e \x01(\tR\x13calculatedWikipedia\x12\x16\n" +
+	"\x06isarea\x18\x0f \x01(\bR\x06isarea\x12:\n" +
+	"\aaddress\x18\x10 \x03(\v2 .nominatim.v1.DetailsAddressLineR\aaddress\x12E\n" +
+	"\rlinked_places\x18\x11 \x03(\v2 .nominatim.v1.DetailsAddressLineR\flinkedPlaces\x129\n" +
+	"\bkeywords\x18\x12 \x01(\v2\x1d.nominatim.v1.DetailsKeywordsR\bkeywords\x12\x1c\n" +
+	"\tlocalname\x18\x13 \x01(\tR\tlocalname\x1a8\n" +
+	"\n" +
+	"NamesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a>\n" +
+	"\x10AddresstagsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xbf\x01\n" +
+	"\x0fDeletableObject\x12\x19\n" +
+	"\bplace_id\x18\x01 \x01(\x03R\aplaceId\x12!\n" +
+	"\fcountry_code\x18\x02 \x01(\tR\vcountryCode\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x15\n" +
+	"\x06osm_id\x18\x04 \x01(\x03R\x05osmId\x12\x19\n" +
+	"\bosm_type\x18\x05 \x01(\tR\aosmType\x12\x14\n" +
+	"\x05class\x18\x06 \x01(\tR\x05class\x12\x12\n" +
+	"\x04type\x18\a \x01(\tR\x04type\"i\n" +
 	"\x11DeletableResponse\x12\x1b\n" +
-	"\tplace_ids\x18\x01 \x03(\x03R\bplaceIds\"/\n" +
+	"\tplace_ids\x18\x01 \x03(\x03R\bplaceIds\x127\n" +
+	"\aobjects\x18\x02 \x03(\v2\x1d.nominatim.v1.DeletableObjectR\aobjects\"^\n" +
+	"\x0fPolygonsRequest\x12\x1b\n" +
+	"\x04days\x18\x01 \x01(\x05B\a\xbaH\x04\x1a\x02(\x00R\x04days\x12\x18\n" +
+	"\areduced\x18\x02 \x01(\bR\areduced\x12\x14\n" +
+	"\x05class\x18\x03 \x01(\tR\x05class\"\x9b\x02\n" +
+	"\fPolygonError\x12\x19\n" +
+	"\bosm_type\x18\x01 \x01(\tR\aosmType\x12\x15\n" +
+	"\x06osm_id\x18\x02 \x01(\x03R\x05osmId\x12\x14\n" +
+	"\x05class\x18\x03 \x01(\tR\x05class\x12\x12\n" +
+	"\x04type\x18\x04 \x01(\tR\x04type\x12\x12\n" +
+	"\x04name\x18\x05 \x01(\tR\x04name\x12!\n" +
+	"\fcountry_code\x18\x06 \x01(\tR\vcountryCode\x12\"\n" +
+	"\ferrormessage\x18\a \x01(\tR\ferrormessage\x12\x18\n" +
+	"\aupdated\x18\b \x01(\tR\aupdated\x12:\n" +
+	"\x0eerror_location\x18\t \x01(\v2\x13.nominatim.v1.PointR\rerrorLocation\"g\n" +
 	"\x10PolygonsResponse\x12\x1b\n" +
-	"\tplace_ids\x18\x01 \x03(\x03R\bplaceIds2\xf9\x04\n" +
+	"\tplace_ids\x18\x01 \x03(\x03R\bplaceIds\x126\n" +
+	"\bpolygons\x18\x02 \x03(\v2\x1a.nominatim.v1.PolygonErrorR\bpolygons\"R\n" +
+	"\n" +
+	"BatchError\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\x05R\x04code\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"X\n" +
+	"\x12BatchSearchRequest\x12B\n" +
+	"\aqueries\x18\x01 \x03(\v2\x1b.nominatim.v1.SearchRequestB\v\xbaH\b\x92\x01\x05\b\x01\x10\xe8\aR\aqueries\"\x86\x01\n" +
+	"\x0fBatchSearchItem\x126\n" +
+	"\x06result\x18\x01 \x01(\v2\x1c.nominatim.v1.SearchResponseH\x00R\x06result\x120\n" +
+	"\x05error\x18\x02 \x01(\v2\x18.nominatim.v1.BatchErrorH\x00R\x05errorB\t\n" +
+	"\aoutcome\"J\n" +
+	"\x13BatchSearchResponse\x123\n" +
+	"\x05items\x18\x01 \x03(\v2\x1d.nominatim.v1.BatchSearchItemR\x05items\"Z\n" +
+	"\x13BatchReverseRequest\x12C\n" +
+	"\aqueries\x18\x01 \x03(\v2\x1c.nominatim.v1.ReverseRequestB\v\xbaH\b\x92\x01\x05\b\x01\x10\xe8\aR\aqueries\"\x88\x01\n" +
+	"\x10BatchReverseItem\x127\n" +
+	"\x06result\x18\x01 \x01(\v2\x1d.nominatim.v1.ReverseResponseH\x00R\x06result\x120\n" +
+	"\x05error\x18\x02 \x01(\v2\x18.nominatim.v1.BatchErrorH\x00R\x05errorB\t\n" +
+	"\aoutcome\"L\n" +
+	"\x14BatchReverseResponse\x124\n" +
+	"\x05items\x18\x01 \x03(\v2\x1e.nominatim.v1.BatchReverseItemR\x05items2\xe0\x06\n" +
 	"\x10NominatimService\x12T\n" +
-	"\x06Search\x12\x1b.nominatim.v1.SearchRequest\x1a\x1c.nominatim.v1.SearchResponse\"\x0f\x82\xd3\xe4\x93\x02\t\x12\a/search\x12X\n" +
+	"\x06Search\x12\x1b.nominatim.v1.SearchRequest\x1a\x1c.nominatim.v1.SearchResponse\"\x0f\x82\xd3\xe4\x93\x02\t\x12\a/search\x12l\n" +
+	"\vBatchSearch\x12 .nominatim.v1.BatchSearchRequest\x1a!.nominatim.v1.BatchSearchResponse\"\x18\x82\xd3\xe4\x93\x02\x12:\x01*\"\r/search/batch\x12X\n" +
 	"\aReverse\x12\x1c.nominatim.v1.ReverseRequest\x1a\x1d.nominatim.v1.ReverseResponse\"\x10\x82\xd3\xe4\x93\x02\n" +
-	"\x12\b/reverse\x12T\n" +
+	"\x12\b/reverse\x12p\n" +
+	"\fBatchReverse\x12!.nominatim.v1.BatchReverseRequest\x1a\".nominatim.v1.BatchReverseResponse\"\x19\x82\xd3\xe4\x93\x02\x13:\x01*\"\x0e/reverse/batch\x12T\n" +
 	"\x06Lookup\x12\x1b.nominatim.v1.LookupRequest\x1a\x1c.nominatim.v1.LookupResponse\"\x0f\x82\xd3\xe4\x93\x02\t\x12\a/lookup\x12T\n" +
 	"\x06Status\x12\x1b.nominatim.v1.StatusRequest\x1a\x1c.nominatim.v1.StatusResponse\"\x0f\x82\xd3\xe4\x93\x02\t\x12\a/status\x12X\n" +
 	"\aDetails\x12\x1c.nominatim.v1.DetailsRequest\x1a\x1d.nominatim.v1.DetailsResponse\"\x10\x82\xd3\xe4\x93\x02\n" +
 	"\x12\b/details\x12X\n" +
 	"\tDeletable\x12\x16.google.protobuf.Empty\x1a\x1f.nominatim.v1.DeletableResponse\"\x12\x82\xd3\xe4\x93\x02\f\x12\n" +
-	"/deletable\x12U\n" +
-	"\bPolygons\x12\x16.google.protobuf.Empty\x1a\x1e.nominatim.v1.PolygonsResponse\"\x11\x82\xd3\xe4\x93\x02\v\x12\t/polygonsB\x95\x01\n" +
+	"/deletable\x12\\\n" +
+	"\bPolygons\x12\x1d.nominatim.v1.PolygonsRequest\x1a\x1e.nominatim.v1.PolygonsResponse\"\x11\x82\xd3\xe4\x93\x02\v\x12\t/polygonsB\x95\x01\n" +
 	"\x10com.nominatim.v1B\x0eNominatimProtoP\x01Z nominatim-go/api/nominatim/v1;v1\xa2\x02\x03NXX\xaa\x02\fNominatim.V1\xca\x02\fNominatim\\V1\xe2\x02\x18Nominatim\\V1\\GPBMetadata\xea\x02\rNominatim::V1b\x06proto3"
 
 var (
@@ -1531,63 +2961,102 @@ func file_nominatim_v1_nominatim_proto_rawDescGZIP() []byte {
 	return file_nominatim_v1_nominatim_proto_rawDescData
 }
 
-var file_nominatim_v1_nominatim_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
+var file_nominatim_v1_nominatim_proto_msgTypes = make([]protoimpl.MessageInfo, 36)
 var file_nominatim_v1_nominatim_proto_goTypes = []any{
-	(*Point)(nil),             // 0: nominatim.v1.Point
-	(*ViewBox)(nil),           // 1: nominatim.v1.ViewBox
-	(*BoundingBox)(nil),       // 2: nominatim.v1.BoundingBox
-	(*Locales)(nil),           // 3: nominatim.v1.Locales
-	(*AddressRow)(nil),        // 4: nominatim.v1.AddressRow
-	(*Place)(nil),             // 5: nominatim.v1.Place
-	(*SearchRequest)(nil),     // 6: nominatim.v1.SearchRequest
-	(*SearchResponse)(nil),    // 7: nominatim.v1.SearchResponse
-	(*ReverseRequest)(nil),    // 8: nominatim.v1.ReverseRequest
-	(*ReverseResponse)(nil),   // 9: nominatim.v1.ReverseResponse
-	(*LookupRequest)(nil),     // 10: nominatim.v1.LookupRequest
-	(*LookupResponse)(nil),    // 11: nominatim.v1.LookupResponse
-	(*StatusRequest)(nil),     // 12: nominatim.v1.StatusRequest
-	(*StatusResponse)(nil),    // 13: nominatim.v1.StatusResponse
-	(*DetailsRequest)(nil),    // 14: nominatim.v1.DetailsRequest
-	(*DetailsResponse)(nil),   // 15: nominatim.v1.DetailsResponse
-	(*DeletableResponse)(nil), // 16: nominatim.v1.DeletableResponse
-	(*PolygonsResponse)(nil),  // 17: nominatim.v1.PolygonsResponse
-	nil,                       // 18: nominatim.v1.Place.ExtratagsEntry
-	nil,                       // 19: nominatim.v1.Place.NamedetailsEntry
-	(*emptypb.Empty)(nil),     // 20: google.protobuf.Empty
+	(*Point)(nil),                // 0: nominatim.v1.Point
+	(*ViewBox)(nil),              // 1: nominatim.v1.ViewBox
+	(*BoundingBox)(nil),          // 2: nominatim.v1.BoundingBox
+	(*Locales)(nil),              // 3: nominatim.v1.Locales
+	(*AddressRow)(nil),           // 4: nominatim.v1.AddressRow
+	(*Place)(nil),                // 5: nominatim.v1.Place
+	(*SearchRequest)(nil),        // 6: nominatim.v1.SearchRequest
+	(*SearchResponse)(nil),       // 7: nominatim.v1.SearchResponse
+	(*ReverseRequest)(nil),       // 8: nominatim.v1.ReverseRequest
+	(*ReverseResponse)(nil),      // 9: nominatim.v1.ReverseResponse
+	(*LookupRequest)(nil),        // 10: nominatim.v1.LookupRequest
+	(*LookupResponse)(nil),       // 11: nominatim.v1.LookupResponse
+	(*StatusRequest)(nil),        // 12: nominatim.v1.StatusRequest
+	(*StatusResponse)(nil),       // 13: nominatim.v1.StatusResponse
+	(*DetailsRequest)(nil),       // 14: nominatim.v1.DetailsRequest
+	(*DetailsAddressLine)(nil),   // 15: nominatim.v1.DetailsAddressLine
+	(*DetailsKeyword)(nil),       // 16: nominatim.v1.DetailsKeyword
+	(*DetailsKeywords)(nil),      // 17: nominatim.v1.DetailsKeywords
+	(*DetailsResponse)(nil),      // 18: nominatim.v1.DetailsResponse
+	(*DeletableObject)(nil),      // 19: nominatim.v1.DeletableObject
+	(*DeletableResponse)(nil),    // 20: nominatim.v1.DeletableResponse
+	(*PolygonsRequest)(nil),      // 21: nominatim.v1.PolygonsRequest
+	(*PolygonError)(nil),         // 22: nominatim.v1.PolygonError
+	(*PolygonsResponse)(nil),     // 23: nominatim.v1.PolygonsResponse
+	(*BatchError)(nil),           // 24: nominatim.v1.BatchError
+	(*BatchSearchRequest)(nil),   // 25: nominatim.v1.BatchSearchRequest
+	(*BatchSearchItem)(nil),      // 26: nominatim.v1.BatchSearchItem
+	(*BatchSearchResponse)(nil),  // 27: nominatim.v1.BatchSearchResponse
+	(*BatchReverseRequest)(nil),  // 28: nominatim.v1.BatchReverseRequest
+	(*BatchReverseItem)(nil),     // 29: nominatim.v1.BatchReverseItem
+	(*BatchReverseResponse)(nil), // 30: nominatim.v1.BatchReverseResponse
+	nil,                          // 31: nominatim.v1.Place.ExtratagsEntry
+	nil,                          // 32: nominatim.v1.Place.NamedetailsEntry
+	nil,                          // 33: nominatim.v1.Place.AddressEntry
+	nil,                          // 34: nominatim.v1.DetailsResponse.NamesEntry
+	nil,                          // 35: nominatim.v1.DetailsResponse.AddresstagsEntry
+	(*emptypb.Empty)(nil),        // 36: google.protobuf.Empty
 }
 var file_nominatim_v1_nominatim_proto_depIdxs = []int32{
 	0,  // 0: nominatim.v1.Place.centroid:type_name -> nominatim.v1.Point
 	2,  // 1: nominatim.v1.Place.boundingbox:type_name -> nominatim.v1.BoundingBox
-	18, // 2: nominatim.v1.Place.extratags:type_name -> nominatim.v1.Place.ExtratagsEntry
-	19, // 3: nominatim.v1.Place.namedetails:type_name -> nominatim.v1.Place.NamedetailsEntry
+	31, // 2: nominatim.v1.Place.extratags:type_name -> nominatim.v1.Place.ExtratagsEntry
+	32, // 3: nominatim.v1.Place.namedetails:type_name -> nominatim.v1.Place.NamedetailsEntry
 	4,  // 4: nominatim.v1.Place.address_rows:type_name -> nominatim.v1.AddressRow
-	3,  // 5: nominatim.v1.SearchRequest.locales:type_name -> nominatim.v1.Locales
-	1,  // 6: nominatim.v1.SearchRequest.viewbox:type_name -> nominatim.v1.ViewBox
-	5,  // 7: nominatim.v1.SearchResponse.results:type_name -> nominatim.v1.Place
-	3,  // 8: nominatim.v1.ReverseRequest.locales:type_name -> nominatim.v1.Locales
-	5,  // 9: nominatim.v1.ReverseResponse.result:type_name -> nominatim.v1.Place
-	3,  // 10: nominatim.v1.LookupRequest.locales:type_name -> nominatim.v1.Locales
-	5,  // 11: nominatim.v1.LookupResponse.results:type_name -> nominatim.v1.Place
-	5,  // 12: nominatim.v1.DetailsResponse.result:type_name -> nominatim.v1.Place
-	6,  // 13: nominatim.v1.NominatimService.Search:input_type -> nominatim.v1.SearchRequest
-	8,  // 14: nominatim.v1.NominatimService.Reverse:input_type -> nominatim.v1.ReverseRequest
-	10, // 15: nominatim.v1.NominatimService.Lookup:input_type -> nominatim.v1.LookupRequest
-	12, // 16: nominatim.v1.NominatimService.Status:input_type -> nominatim.v1.StatusRequest
-	14, // 17: nominatim.v1.NominatimService.Details:input_type -> nominatim.v1.DetailsRequest
-	20, // 18: nominatim.v1.NominatimService.Deletable:input_type -> google.protobuf.Empty
-	20, // 19: nominatim.v1.NominatimService.Polygons:input_type -> google.protobuf.Empty
-	7,  // 20: nominatim.v1.NominatimService.Search:output_type -> nominatim.v1.SearchResponse
-	9,  // 21: nominatim.v1.NominatimService.Reverse:output_type -> nominatim.v1.ReverseResponse
-	11, // 22: nominatim.v1.NominatimService.Lookup:output_type -> nominatim.v1.LookupResponse
-	13, // 23: nominatim.v1.NominatimService.Status:output_type -> nominatim.v1.StatusResponse
-	15, // 24: nominatim.v1.NominatimService.Details:output_type -> nominatim.v1.DetailsResponse
-	16, // 25: nominatim.v1.NominatimService.Deletable:output_type -> nominatim.v1.DeletableResponse
-	17, // 26: nominatim.v1.NominatimService.Polygons:output_type -> nominatim.v1.PolygonsResponse
-	20, // [20:27] is the sub-list for method output_type
-	13, // [13:20] is the sub-list for method input_type
-	13, // [13:13] is the sub-list for extension type_name
-	13, // [13:13] is the sub-list for extension extendee
-	0,  // [0:13] is the sub-list for field type_name
+	33, // 5: nominatim.v1.Place.address:type_name -> nominatim.v1.Place.AddressEntry
+	3,  // 6: nominatim.v1.SearchRequest.locales:type_name -> nominatim.v1.Locales
+	1,  // 7: nominatim.v1.SearchRequest.viewbox:type_name -> nominatim.v1.ViewBox
+	5,  // 8: nominatim.v1.SearchResponse.results:type_name -> nominatim.v1.Place
+	3,  // 9: nominatim.v1.ReverseRequest.locales:type_name -> nominatim.v1.Locales
+	5,  // 10: nominatim.v1.ReverseResponse.result:type_name -> nominatim.v1.Place
+	3,  // 11: nominatim.v1.LookupRequest.locales:type_name -> nominatim.v1.Locales
+	5,  // 12: nominatim.v1.LookupResponse.results:type_name -> nominatim.v1.Place
+	16, // 13: nominatim.v1.DetailsKeywords.name:type_name -> nominatim.v1.DetailsKeyword
+	16, // 14: nominatim.v1.DetailsKeywords.address:type_name -> nominatim.v1.DetailsKeyword
+	5,  // 15: nominatim.v1.DetailsResponse.result:type_name -> nominatim.v1.Place
+	34, // 16: nominatim.v1.DetailsResponse.names:type_name -> nominatim.v1.DetailsResponse.NamesEntry
+	35, // 17: nominatim.v1.DetailsResponse.addresstags:type_name -> nominatim.v1.DetailsResponse.AddresstagsEntry
+	15, // 18: nominatim.v1.DetailsResponse.address:type_name -> nominatim.v1.DetailsAddressLine
+	15, // 19: nominatim.v1.DetailsResponse.linked_places:type_name -> nominatim.v1.DetailsAddressLine
+	17, // 20: nominatim.v1.DetailsResponse.keywords:type_name -> nominatim.v1.DetailsKeywords
+	19, // 21: nominatim.v1.DeletableResponse.objects:type_name -> nominatim.v1.DeletableObject
+	0,  // 22: nominatim.v1.PolygonError.error_location:type_name -> nominatim.v1.Point
+	22, // 23: nominatim.v1.PolygonsResponse.polygons:type_name -> nominatim.v1.PolygonError
+	6,  // 24: nominatim.v1.BatchSearchRequest.queries:type_name -> nominatim.v1.SearchRequest
+	7,  // 25: nominatim.v1.BatchSearchItem.result:type_name -> nominatim.v1.SearchResponse
+	24, // 26: nominatim.v1.BatchSearchItem.error:type_name -> nominatim.v1.BatchError
+	26, // 27: nominatim.v1.BatchSearchResponse.items:type_name -> nominatim.v1.BatchSearchItem
+	8,  // 28: nominatim.v1.BatchReverseRequest.queries:type_name -> nominatim.v1.ReverseRequest
+	9,  // 29: nominatim.v1.BatchReverseItem.result:type_name -> nominatim.v1.ReverseResponse
+	24, // 30: nominatim.v1.BatchReverseItem.error:type_name -> nominatim.v1.BatchError
+	29, // 31: nominatim.v1.BatchReverseResponse.items:type_name -> nominatim.v1.BatchReverseItem
+	6,  // 32: nominatim.v1.NominatimService.Search:input_type -> nominatim.v1.SearchRequest
+	25, // 33: nominatim.v1.NominatimService.BatchSearch:input_type -> nominatim.v1.BatchSearchRequest
+	8,  // 34: nominatim.v1.NominatimService.Reverse:input_type -> nominatim.v1.ReverseRequest
+	28, // 35: nominatim.v1.NominatimService.BatchReverse:input_type -> nominatim.v1.BatchReverseRequest
+	10, // 36: nominatim.v1.NominatimService.Lookup:input_type -> nominatim.v1.LookupRequest
+	12, // 37: nominatim.v1.NominatimService.Status:input_type -> nominatim.v1.StatusRequest
+	14, // 38: nominatim.v1.NominatimService.Details:input_type -> nominatim.v1.DetailsRequest
+	36, // 39: nominatim.v1.NominatimService.Deletable:input_type -> google.protobuf.Empty
+	21, // 40: nominatim.v1.NominatimService.Polygons:input_type -> nominatim.v1.PolygonsRequest
+	7,  // 41: nominatim.v1.NominatimService.Search:output_type -> nominatim.v1.SearchResponse
+	27, // 42: nominatim.v1.NominatimService.BatchSearch:output_type -> nominatim.v1.BatchSearchResponse
+	9,  // 43: nominatim.v1.NominatimService.Reverse:output_type -> nominatim.v1.ReverseResponse
+	30, // 44: nominatim.v1.NominatimService.BatchReverse:output_type -> nominatim.v1.BatchReverseResponse
+	11, // 45: nominatim.v1.NominatimService.Lookup:output_type -> nominatim.v1.LookupResponse
+	13, // 46: nominatim.v1.NominatimService.Status:output_type -> nominatim.v1.StatusResponse
+	18, // 47: nominatim.v1.NominatimService.Details:output_type -> nominatim.v1.DetailsResponse
+	20, // 48: nominatim.v1.NominatimService.Deletable:output_type -> nominatim.v1.DeletableResponse
+	23, // 49: nominatim.v1.NominatimService.Polygons:output_type -> nominatim.v1.PolygonsResponse
+	41, // [41:50] is the sub-list for method output_type
+	32, // [32:41] is the sub-list for method input_type
+	32, // [32:32] is the sub-list for extension type_name
+	32, // [32:32] is the sub-list for extension extendee
+	0,  // [0:32] is the sub-list for field type_name
 }
 
 func init() { file_nominatim_v1_nominatim_proto_init() }
@@ -1595,13 +3064,22 @@ func file_nominatim_v1_nominatim_proto_init() {
 	if File_nominatim_v1_nominatim_proto != nil {
 		return
 	}
+	file_nominatim_v1_nominatim_proto_msgTypes[14].OneofWrappers = []any{}
+	file_nominatim_v1_nominatim_proto_msgTypes[26].OneofWrappers = []any{
+		(*BatchSearchItem_Result)(nil),
+		(*BatchSearchItem_Error)(nil),
+	}
+	file_nominatim_v1_nominatim_proto_msgTypes[29].OneofWrappers = []any{
+		(*BatchReverseItem_Result)(nil),
+		(*BatchReverseItem_Error)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_nominatim_v1_nominatim_proto_rawDesc), len(file_nominatim_v1_nominatim_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   20,
+			NumMessages:   36,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -20,13 +20,15 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	NominatimService_Search_FullMethodName    = "/nominatim.v1.NominatimService/Search"
-	NominatimService_Reverse_FullMethodName   = "/nominatim.v1.NominatimService/Reverse"
-	NominatimService_Lookup_FullMethodName    = "/nominatim.v1.NominatimService/Lookup"
-	NominatimService_Status_FullMethodName    = "/nominatim.v1.NominatimService/Status"
-	NominatimService_Details_FullMethodName   = "/nominatim.v1.NominatimService/Details"
-	NominatimService_Deletable_FullMethodName = "/nominatim.v1.NominatimService/Deletable"
-	NominatimService_Polygons_FullMethodName  = "/nominatim.v1.NominatimService/Polygons"
+	NominatimService_Search_FullMethodName       = "/nominatim.v1.NominatimService/Search"
+	NominatimService_BatchSearch_FullMethodName  = "/nominatim.v1.NominatimService/BatchSearch"
+	NominatimService_Reverse_FullMethodName      = "/nominatim.v1.NominatimService/Reverse"
+	NominatimService_BatchReverse_FullMethodName = "/nominatim.v1.NominatimService/BatchReverse"
+	NominatimService_Lookup_FullMethodName       = "/nominatim.v1.NominatimService/Lookup"
+	NominatimService_Status_FullMethodName       = "/nominatim.v1.NominatimService/Status"
+	NominatimService_Details_FullMethodName      = "/nominatim.v1.NominatimService/Details"
+	NominatimService_Deletable_FullMethodName    = "/nominatim.v1.NominatimService/Deletable"
+	NominatimService_Polygons_FullMethodName     = "/nominatim.v1.NominatimService/Polygons"
 )
 
 // NominatimServiceClient is the client API for NominatimService service.
@@ -37,8 +39,12 @@ const (
 type NominatimServiceClient interface {
 	// 名称/地址/类型搜索
 	Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error)
+	// 批量搜索（每项独立参数，有限并发执行）
+	BatchSearch(ctx context.Context, in *BatchSearchRequest, opts ...grpc.CallOption) (*BatchSearchResponse, error)
 	// 逆地理编码：经纬度到地点
 	Reverse(ctx context.Context, in *ReverseRequest, opts ...grpc.CallOption) (*ReverseResponse, error)
+	// 批量逆地理编码（每项独立参数，有限并发执行）
+	BatchReverse(ctx context.Context, in *BatchReverseRequest, opts ...grpc.CallOption) (*BatchReverseResponse, error)
 	// 依据 OSM ID 批量查询
 	Lookup(ctx context.Context, in *LookupRequest, opts ...grpc.CallOption) (*LookupResponse, error)
 	// 服务状态
@@ -48,7 +54,7 @@ type NominatimServiceClient interface {
 	// 可删除对象列表（维护用途）
 	Deletable(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*DeletableResponse, error)
 	// 问题多边形列表（维护用途）
-	Polygons(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PolygonsResponse, error)
+	Polygons(ctx context.Context, in *PolygonsRequest, opts ...grpc.CallOption) (*PolygonsResponse, error)
 }
 
 type nominatimServiceClient struct {
@@ -69,6 +75,16 @@ func (c *nominatimServiceClient) Search(ctx context.Context, in *SearchRequest,
 	return out, nil
 }
 
+func (c *nominatimServiceClient) BatchSearch(ctx context.Context, in *BatchSearchRequest, opts ...grpc.CallOption) (*BatchSearchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchSearchResponse)
+	err := c.cc.Invoke(ctx, NominatimService_BatchSearch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *nominatimServiceClient) Reverse(ctx context.Context, in *ReverseRequest, opts ...grpc.CallOption) (*ReverseResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ReverseResponse)
@@ -79,6 +95,16 @@ func (c *nominatimServiceClient) Reverse(ctx context.Context, in *ReverseRequest
 	return out, nil
 }
 
+func (c *nominatimServiceClient) BatchReverse(ctx context.Context, in *BatchReverseRequest, opts ...grpc.CallOption) (*BatchReverseResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchReverseResponse)
+	err := c.cc.Invoke(ctx, NominatimService_BatchReverse_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *nominatimServiceClient) Lookup(ctx context.Context, in *LookupRequest, opts ...grpc.CallOption) (*LookupResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(LookupResponse)
@@ -119,7 +145,7 @@ func (c *nominatimServiceClient) Deletable(ctx context.Context, in *emptypb.Empt
 	return out, nil
 }
 
-func (c *nominatimServiceClient) Polygons(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PolygonsResponse, error) {
+func (c *nominatimServiceClient) Polygons(ctx context.Context, in *PolygonsRequest, opts ...grpc.CallOption) (*PolygonsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(PolygonsResponse)
 	err := c.cc.Invoke(ctx, NominatimService_Polygons_FullMethodName, in, out, cOpts...)
@@ -137,8 +163,12 @@ func (c *nominatimServiceClient) Polygons(ctx context.Context, in *emptypb.Empty
 type NominatimServiceServer interface {
 	// 名称/地址/类型搜索
 	Search(context.Context, *SearchRequest) (*SearchResponse, error)
+	// 批量搜索（每项独立参数，有限并发执行）
+	BatchSearch(context.Context, *BatchSearchRequest) (*BatchSearchResponse, error)
 	// 逆地理编码：经纬度到地点
 	Reverse(context.Context, *ReverseRequest) (*ReverseResponse, error)
+	// 批量逆地理编码（每项独立参数，有限并发执行）
+	BatchReverse(context.Context, *BatchReverseRequest) (*BatchReverseResponse, error)
 	// 依据 OSM ID 批量查询
 	Lookup(context.Context, *LookupRequest) (*LookupResponse, error)
 	// 服务状态
@@ -148,7 +178,7 @@ type NominatimServiceServer interface {
 	// 可删除对象列表（维护用途）
 	Deletable(context.Context, *emptypb.Empty) (*DeletableResponse, error)
 	// 问题多边形列表（维护用途）
-	Polygons(context.Context, *emptypb.Empty) (*PolygonsResponse, error)
+	Polygons(context.Context, *PolygonsRequest) (*PolygonsResponse, error)
 	mustEmbedUnimplementedNominatimServiceServer()
 }
 
@@ -162,9 +192,15 @@ type UnimplementedNominatimServiceServer struct{}
 func (UnimplementedNominatimServiceServer) Search(context.Context, *SearchRequest) (*SearchResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Search not implemented")
 }
+func (UnimplementedNominatimServiceServer) BatchSearch(context.Context, *BatchSearchRequest) (*BatchSearchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchSearch not implemented")
+}
 func (UnimplementedNominatimServiceServer) Reverse(context.Context, *ReverseRequest) (*ReverseResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Reverse not implemented")
 }
+func (UnimplementedNominatimServiceServer) BatchReverse(context.Context, *BatchReverseRequest) (*BatchReverseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchReverse not implemented")
+}
 func (UnimplementedNominatimServiceServer) Lookup(context.Context, *LookupRequest) (*LookupResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Lookup not implemented")
 }
@@ -177,7 +213,7 @@ func (UnimplementedNominatimServiceServer) Details(context.Context, *DetailsRequ
 func (UnimplementedNominatimServiceServer) Deletable(context.Context, *emptypb.Empty) (*DeletableResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Deletable not implemented")
 }
-func (UnimplementedNominatimServiceServer) Polygons(context.Context, *emptypb.Empty) (*PolygonsResponse, error) {
+func (UnimplementedNominatimServiceServer) Polygons(context.Context, *PolygonsRequest) (*PolygonsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Polygons not implemented")
 }
 func (UnimplementedNominatimServiceServer) mustEmbedUnimplementedNominatimServiceServer() {}
@@ -219,6 +255,24 @@ func _NominatimService_Search_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NominatimService_BatchSearch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchSearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NominatimServiceServer).BatchSearch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NominatimService_BatchSearch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NominatimServiceServer).BatchSearch(ctx, req.(*BatchSearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _NominatimService_Reverse_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ReverseRequest)
 	if err := dec(in); err != nil {
@@ -237,6 +291,24 @@ func _NominatimService_Reverse_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NominatimService_BatchReverse_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchReverseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NominatimServiceServer).BatchReverse(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NominatimService_BatchReverse_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NominatimServiceServer).BatchReverse(ctx, req.(*BatchReverseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _NominatimService_Lookup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(LookupRequest)
 	if err := dec(in); err != nil {
@@ -310,7 +382,7 @@ func _NominatimService_Deletable_Handler(srv interface{}, ctx context.Context, d
 }
 
 func _NominatimService_Polygons_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
+	in := new(PolygonsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -322,7 +394,7 @@ func _NominatimService_Polygons_Handler(srv interface{}, ctx context.Context, de
 		FullMethod: NominatimService_Polygons_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NominatimServiceServer).Polygons(ctx, req.(*emptypb.Empty))
+		return srv.(NominatimServiceServer).Polygons(ctx, req.(*PolygonsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -338,10 +410,18 @@ var NominatimService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Search",
 			Handler:    _NominatimService_Search_Handler,
 		},
+		{
+			MethodName: "BatchSearch",
+			Handler:    _NominatimService_BatchSearch_Handler,
+		},
 		{
 			MethodName: "Reverse",
 			Handler:    _NominatimService_Reverse_Handler,
 		},
+		{
+			MethodName: "BatchReverse",
+			Handler:    _NominatimService_BatchReverse_Handler,
+		},
 		{
 			MethodName: "Lookup",
 			Handler:    _NominatimService_Lookup_Handler,
@@ -20,6 +20,8 @@ var _ = binding.EncodeURL
 
 const _ = http.SupportPackageIsVersion1
 
+const OperationNominatimServiceBatchReverse = "/nominatim.v1.NominatimService/BatchReverse"
+const OperationNominatimServiceBatchSearch = "/nominatim.v1.NominatimService/BatchSearch"
 const OperationNominatimServiceDeletable = "/nominatim.v1.NominatimService/Deletable"
 const OperationNominatimServiceDetails = "/nominatim.v1.NominatimService/Details"
 const OperationNominatimServiceLookup = "/nominatim.v1.NominatimService/Lookup"
@@ -29,6 +31,10 @@ const OperationNominatimServiceSearch = "/nominatim.v1.NominatimService/Search"
 const OperationNominatimServiceStatus = "/nominatim.v1.NominatimService/Status"
 
 type NominatimServiceHTTPServer interface {
+	// BatchReverse 批量逆地理编码（每项独立参数，有限并发执行）
+	BatchReverse(context.Context, *BatchReverseRequest) (*BatchReverseResponse, error)
+	// BatchSearch 批量搜索（每项独立参数，有限并发执行）
+	BatchSearch(context.Context, *BatchSearchRequest) (*BatchSearchResponse, error)
 	// Deletable 可删除对象列表（维护用途）
 	Deletable(context.Context, *emptypb.Empty) (*DeletableResponse, error)
 	// Details 对象详情（调试用）
@@ -36,7 +42,7 @@ type NominatimServiceHTTPServer interface {
 	// Lookup 依据 OSM ID 批量查询
 	Lookup(context.Context, *LookupRequest) (*LookupResponse, error)
 	// Polygons 问题多边形列表（维护用途）
-	Polygons(context.Context, *emptypb.Empty) (*PolygonsResponse, error)
+	Polygons(context.Context, *PolygonsRequest) (*PolygonsResponse, error)
 	// Reverse 逆地理编码：经纬度到地点
 	Reverse(context.Context, *ReverseRequest) (*ReverseResponse, error)
 	// Search 名称/地址/类型搜索
@@ -48,7 +54,9 @@ type NominatimServiceHTTPServer interface {
 func RegisterNominatimServiceHTTPServer(s *http.Server, srv NominatimServiceHTTPServer) {
 	r := s.Route("/")
 	r.GET("/search", _NominatimService_Search0_HTTP_Handler(srv))
+	r.POST("/search/batch", _NominatimService_BatchSearch0_HTTP_Handler(srv))
 	r.GET("/reverse", _NominatimService_Reverse0_HTTP_Handler(srv))
+	r.POST("/reverse/batch", _NominatimService_BatchReverse0_HTTP_Handler(srv))
 	r.GET("/lookup", _NominatimService_Lookup0_HTTP_Handler(srv))
 	r.GET("/status", _NominatimService_Status0_HTTP_Handler(srv))
 	r.GET("/details", _NominatimService_Details0_HTTP_Handler(srv))
@@ -75,6 +83,28 @@ func _NominatimService_Search0_HTTP_Handler(srv NominatimServiceHTTPServer) func
 	}
 }
 
+func _NominatimService_BatchSearch0_HTTP_Handler(srv NominatimServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in BatchSearchRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationNominatimServiceBatchSearch)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.BatchSearch(ctx, req.(*BatchSearchRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*BatchSearchResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
 func _NominatimService_Reverse0_HTTP_Handler(srv NominatimServiceHTTPServer) func(ctx http.Context) error {
 	return func(ctx http.Context) error {
 		var in ReverseRequest
@@ -94,6 +124,28 @@ func _NominatimService_Reverse0_HTTP_Handler(srv NominatimServiceHTTPServer) fun
 	}
 }
 
+func _NominatimService_BatchReverse0_HTTP_Handler(srv NominatimServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in BatchReverseRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationNominatimServiceBatchReverse)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.BatchReverse(ctx, req.(*BatchReverseRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*BatchReverseResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
 func _NominatimService_Lookup0_HTTP_Handler(srv NominatimServiceHTTPServer) func(ctx http.Context) error {
 	return func(ctx http.Context) error {
 		var in LookupRequest
@@ -172,13 +224,13 @@ func _NominatimService_Deletable0_HTTP_Handler(srv NominatimServiceHTTPServer) f
 
 func _NominatimService_Polygons0_HTTP_Handler(srv NominatimServiceHTTPServer) func(ctx http.Context) error {
 	return func(ctx http.Context) error {
-		var in emptypb.Empty
+		var in PolygonsRequest
 		if err := ctx.BindQuery(&in); err != nil {
 			return err
 		}
 		http.SetOperation(ctx, OperationNominatimServicePolygons)
 		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
-			return srv.Polygons(ctx, req.(*emptypb.Empty))
+			return srv.Polygons(ctx, req.(*PolygonsRequest))
 		})
 		out, err := h(ctx, &in)
 		if err != nil {
@@ -190,6 +242,10 @@ func _NominatimService_Polygons0_HTTP_Handler(srv NominatimServiceHTTPServer) fu
 }
 
 type NominatimServiceHTTPClient interface {
+	// BatchReverse 批量逆地理编码（每项独立参数，有限并发执行）
+	BatchReverse(ctx context.Context, req *BatchReverseRequest, opts ...http.CallOption) (rsp *BatchReverseResponse, err error)
+	// BatchSearch 批量搜索（每项独立参数，有限并发执行）
+	BatchSearch(ctx context.Context, req *BatchSearchRequest, opts ...http.CallOption) (rsp *BatchSearchResponse, err error)
 	// Deletable 可删除对象列表（维护用途）
 	Deletable(ctx context.Context, req *emptypb.Empty, opts ...http.CallOption) (rsp *DeletableResponse, err error)
 	// Details 对象详情（调试用）
@@ -197,7 +253,7 @@ type NominatimServiceHTTPClient interface {
 	// Lookup 依据 OSM ID 批量查询
 	Lookup(ctx context.Context, req *LookupRequest, opts ...http.CallOption) (rsp *LookupResponse, err error)
 	// Polygons 问题多边形列表（维护用途）
-	Polygons(ctx context.Context, req *emptypb.Empty, opts ...http.CallOption) (rsp *PolygonsResponse, err error)
+	Polygons(ctx context.Context, req *PolygonsRequest, opts ...http.CallOption) (rsp *PolygonsResponse, err error)
 	// Reverse 逆地理编码：经纬度到地点
 	Reverse(ctx context.Context, req *ReverseRequest, opts ...http.CallOption) (rsp *ReverseResponse, err error)
 	// Search 名称/地址/类型搜索
@@ -214,6 +270,34 @@ func NewNominatimServiceHTTPClient(client *http.Client) NominatimServiceHTTPClie
 	return &NominatimServiceHTTPClientImpl{client}
 }
 
+// BatchReverse 批量逆地理编码（每项独立参数，有限并发执行）
+func (c *NominatimServiceHTTPClientImpl) BatchReverse(ctx context.Context, in *BatchReverseRequest, opts ...http.CallOption) (*BatchReverseResponse, error) {
+	var out BatchReverseResponse
+	pattern := "/reverse/batch"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationNominatimServiceBatchReverse))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// BatchSearch 批量搜索（每项独立参数，有限并发执行）
+func (c *NominatimServiceHTTPClientImpl) BatchSearch(ctx context.Context, in *BatchSearchRequest, opts ...http.CallOption) (*BatchSearchResponse, error) {
+	var out BatchSearchResponse
+	pattern := "/search/batch"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationNominatimServiceBatchSearch))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 // Deletable 可删除对象列表（维护用途）
 func (c *NominatimServiceHTTPClientImpl) Deletable(ctx context.Context, in *emptypb.Empty, opts ...http.CallOption) (*DeletableResponse, error) {
 	var out DeletableResponse
@@ -257,7 +341,7 @@ func (c *NominatimServiceHTTPClientImpl) Lookup(ctx context.Context, in *LookupR
 }
 
 // Polygons 问题多边形列表（维护用途）
-func (c *NominatimServiceHTTPClientImpl) Polygons(ctx context.Context, in *emptypb.Empty, opts ...http.CallOption) (*PolygonsResponse, error) {
+func (c *NominatimServiceHTTPClientImpl) Polygons(ctx context.Context, in *PolygonsRequest, opts ...http.CallOption) (*PolygonsResponse, error) {
 	var out PolygonsResponse
 	pattern := "/polygons"
 	path := binding.EncodeURL(pattern, in, true)
@@ -47,6 +47,31 @@ func newApp(logger log.Logger, gs *grpc.Server, hs *http.Server) *kratos.App {
 	)
 }
 
+// watchRuntime 监听可热更新的配置段（限流、开关、licence 等），变化后重新加载并应用环境变量覆盖。
+func watchRuntime(c config.Config, rt *conf.Runtime, logger log.Logger) {
+	helper := log.NewHelper(logger)
+	reload := func(key string, _ config.Value) {
+		var bc conf.Bootstrap
+		if err := c.Scan(&bc); err != nil {
+			helper.Errorf("reload config (%s): %v", key, err)
+			return
+		}
+		conf.ApplyEnv(&bc)
+		// 非法配置不生效，继续使用原配置
+		if err := bc.GetServer().GetRateLimit().Validate(); err != nil {
+			helper.Errorf("reload config (%s): %v", key, err)
+			return
+		}
+		rt.Update(&bc)
+		helper.Infof("config reloaded: %s", key)
+	}
+	for _, key := range []string{"nominatim", "server.rate_limit"} {
+		if err := c.Watch(key, reload); err != nil {
+			helper.Warnf("config key %s is not watched: %v", key, err)
+		}
+	}
+}
+
 func main() {
 	flag.Parse()
 	logger := log.With(log.NewStdLogger(os.Stdout),
@@ -73,8 +98,11 @@ func main() {
 	if err := c.Scan(&bc); err != nil {
 		panic(err)
 	}
+	conf.ApplyEnv(&bc)
+	rt := conf.NewRuntime(&bc)
+	watchRuntime(c, rt, logger)
 
-	app, cleanup, err := wireApp(bc.Server, bc.Data, logger)
+	app, cleanup, err := wireApp(bc.Server, bc.Data, rt, logger)
 	if err != nil {
 		panic(err)
 	}
@@ -23,7 +23,20 @@ import (
 // Injectors from wire.go:
 
 // wireApp init kratos application.
-func wireApp(confServer *conf.Server, confData *conf.Data, logger log.Logger) (*kratos.App, func(), error) {
+func wireApp(confServer *conf.Server, confData *conf.Data, runtime *conf.Runtime, logger log.Logger) (*kratos.App, func(), error) {
+	authenticator := server.NewAuthenticator(confServer)
+	rateLimiter, err := server.NewRateLimiter(runtime, authenticator)
+	if err != nil {
+		return nil, nil, err
+	}
+	validator, err := server.NewValidator()
+	if err != nil {
+		return nil, nil, err
+	}
+	config, err := server.NewServerTLSConfig(confServer)
+	if err != nil {
+		return nil, nil, err
+	}
 	driver := data.NewSqlDriver(confData)
 	dataData, cleanup, err := data.NewData(confData, driver, logger)
 	if err != nil {
@@ -33,10 +46,13 @@ func wireApp(confServer *conf.Server, confData *conf.Data, logger log.Logger) (*
 	greeterUsecase := biz.NewGreeterUsecase(greeterRepo, logger)
 	greeterService := service.NewGreeterService(greeterUsecase)
 	searchRepo := data.NewSearchRepo(dataData)
-	searchUsecase := biz.NewSearchUsecase(searchRepo, logger)
-	nominatimService := service.NewNominatimService(logger, searchUsecase, dataData)
-	grpcServer := server.NewGRPCServer(confServer, greeterService, nominatimService, logger)
-	httpServer := server.NewHTTPServer(confServer, greeterService, nominatimService, logger)
+	searchCache := data.NewSearchCache(dataData, confData, logger)
+	searchUsecase := biz.NewSearchUsecase(searchRepo, searchCache, logger)
+	maintenanceRepo := data.NewMaintenanceRepo(dataData)
+	maintenanceUsecase := biz.NewMaintenanceUsecase(maintenanceRepo, logger)
+	nominatimService := service.NewNominatimService(logger, runtime, searchUsecase, maintenanceUsecase, dataData)
+	grpcServer := server.NewGRPCServer(confServer, rateLimiter, authenticator, validator, config, greeterService, nominatimService, logger)
+	httpServer := server.NewHTTPServer(confServer, rateLimiter, authenticator, validator, config, greeterService, nominatimService, logger)
 	app := newApp(logger, grpcServer, httpServer)
 	return app, func() {
 		cleanup()
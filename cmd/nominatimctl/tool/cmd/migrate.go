@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"nominatim-go/internal/conf"
+	"nominatim-go/internal/data"
+
+	"github.com/go-kratos/kratos/v2/config"
+	"github.com/go-kratos/kratos/v2/config/file"
+	"github.com/spf13/cobra"
+)
+
+// migrateCmd creates/updates the ent-managed application tables
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "创建/更新应用自有表（ent 管理，使用 data.app_database；服务启动时不再自动迁移）",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c := config.New(config.WithSource(file.NewSource(cfgPath)))
+		defer c.Close()
+		if err := c.Load(); err != nil {
+			return fmt.Errorf("加载配置失败：%w", err)
+		}
+		var bc conf.Bootstrap
+		if err := c.Scan(&bc); err != nil {
+			return fmt.Errorf("解析配置失败：%w", err)
+		}
+		conf.ApplyEnv(&bc)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+		defer cancel()
+		if err := data.Migrate(ctx, bc.Data); err != nil {
+			return fmt.Errorf("迁移失败：%w", err)
+		}
+		fmt.Println("migrate: 应用表已是最新")
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(migrateCmd)
+}
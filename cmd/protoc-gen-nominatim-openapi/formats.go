@@ -0,0 +1,311 @@
+package main
+
+// 以下为 HTTP 编码层（internal/server/encoders.go）的约定，proto 中无法表达：
+// 各路由支持的 format、编码器额外读取的查询参数，以及每种格式的响应结构与示例。
+
+// outputFormat 一种 format 取值的响应形式。
+type outputFormat struct {
+	name        string // format 参数取值；"" 为未指定 format 时的 protojson
+	contentType string
+	schema      *schema
+	summary     string
+}
+
+var (
+	formatDefault     = outputFormat{contentType: "application/json", summary: "未指定 format：protojson（字段名为 lowerCamelCase，int64 为字符串）"}
+	formatJSON        = outputFormat{name: "json", contentType: "application/json", schema: ref("NominatimPlaceList"), summary: "format=json：Nominatim json（类别字段为 class）"}
+	formatJSONv2      = outputFormat{name: "jsonv2", contentType: "application/json", schema: ref("NominatimPlaceList"), summary: "format=jsonv2：Nominatim jsonv2（类别字段为 category）"}
+	formatGeoJSON     = outputFormat{name: "geojson", contentType: "application/json", schema: ref("GeoJSONFeatureCollection"), summary: "format=geojson：GeoJSON FeatureCollection"}
+	formatGeocodeJSON = outputFormat{name: "geocodejson", contentType: "application/json", schema: ref("GeocodeJSONFeatureCollection"), summary: "format=geocodejson：GeocodeJSON 0.1.0"}
+	formatXML         = outputFormat{name: "xml", contentType: "application/xml", schema: &schema{Type: "string"}, summary: "format=xml：Nominatim XML"}
+	formatHTML        = outputFormat{name: "html", contentType: "text/html", schema: &schema{Type: "string"}, summary: "format=html：HTML 表格"}
+	formatText        = outputFormat{name: "text", contentType: "text/plain", schema: &schema{Type: "string"}, summary: "format=text：制表符分隔文本（首行为表头）"}
+)
+
+// route 一个 RPC 在 HTTP 上的输出约定。
+type route struct {
+	tag      string
+	formats  []outputFormat
+	extra    []*parameter      // 编码器额外读取的查询参数（不在请求消息中）
+	examples map[string]string // format -> 示例响应体（"" 为 protojson）
+	notFound [2]string         // 无结果时的 404：说明与错误信息（空表示不返回 404）
+}
+
+// placeFormats 地点列表（search/lookup）支持的全部格式；reverseFormats 中 json/jsonv2 为单个对象。
+var (
+	placeFormats   = []outputFormat{formatDefault, formatJSON, formatJSONv2, formatGeoJSON, formatGeocodeJSON, formatXML}
+	reverseFormats = []outputFormat{formatDefault, withSchema(formatJSON, "NominatimPlace"), withSchema(formatJSONv2, "NominatimPlace"), formatGeoJSON, formatGeocodeJSON, formatXML}
+)
+
+func withSchema(f outputFormat, name string) outputFormat {
+	f.schema = ref(name)
+	return f
+}
+
+// polygonParams 地点类结果的附加多边形输出（需同时 polygon_geojson=1）与 JSONP 回调。
+var polygonParams = []*parameter{
+	flagParam("polygon_text", "附加 WKT 风格的多边形文本（json/jsonv2 为 geotext，geojson/geocodejson 为 polygon）"),
+	flagParam("polygon_svg", "附加 SVG path 片段（svg）"),
+	flagParam("polygon_kml", "附加 KML Polygon 片段（json/jsonv2 为 geokml，geojson/geocodejson 为 kml）"),
+	{Name: "json_callback", In: "query", Description: "JSONP 回调函数名（json/jsonv2/geojson/geocodejson）", Schema: &schema{Type: "string", Pattern: `^[$_\p{L}][$_\p{L}\p{Nl}\p{Nd}.\[\]]*$`}},
+}
+
+// routes 按 RPC 名称索引；未列出的 RPC 仅输出 protojson。
+var routes = map[string]route{
+	"Search": {
+		tag:     "search",
+		formats: placeFormats,
+		extra:   polygonParams,
+		examples: map[string]string{
+			"":            `{"results":[` + examplePlace + `]}`,
+			"json":        `[` + exampleNominatimPlace("class", true) + `]`,
+			"jsonv2":      `[` + exampleNominatimPlace("category", true) + `]`,
+			"geojson":     exampleGeoJSON,
+			"geocodejson": exampleGeocodeJSON,
+			"xml": `<searchresults more_url="/search?addressdetails=1&amp;exclude_place_ids=159349938&amp;format=xml&amp;q=Berlin" exclude_place_ids="159349938">
+  <result place_id="159349938" osm_type="relation" osm_id="62422" display_name="Berlin, Deutschland" class="boundary" type="administrative" importance="0.8544" lat="52.5173885" lon="13.3951309" boundingbox="52.3382448,52.6755087,13.088345,13.7611609">
+    <city>Berlin</city>
+    <ISO3166-2-lvl4>DE-BE</ISO3166-2-lvl4>
+    <country>Deutschland</country>
+    <country_code>de</country_code>
+  </result>
+</searchresults>`,
+		},
+	},
+	"Reverse": {
+		tag:     "reverse",
+		formats: reverseFormats,
+		extra:   polygonParams,
+		examples: map[string]string{
+			"":            `{"result":` + examplePlace + `}`,
+			"json":        exampleNominatimPlace("class", false),
+			"jsonv2":      exampleNominatimPlace("category", false),
+			"geojson":     exampleGeoJSON,
+			"geocodejson": exampleGeocodeJSON,
+			"xml": `<reversegeocode>
+  <result place_id="159349938" osm_type="relation" osm_id="62422" display_name="Berlin, Deutschland" class="boundary" type="administrative" importance="0.8544" lat="52.5173885" lon="13.3951309" boundingbox="52.3382448,52.6755087,13.088345,13.7611609"></result>
+  <addressparts>
+    <city>Berlin</city>
+    <ISO3166-2-lvl4>DE-BE</ISO3166-2-lvl4>
+    <country>Deutschland</country>
+    <country_code>de</country_code>
+  </addressparts>
+</reversegeocode>`,
+			"miss": `{"error":"Unable to geocode"}`,
+		},
+		notFound: [2]string{"无结果。指定 format 时与 Nominatim 一致返回 200 与 {\"error\":\"Unable to geocode\"}", "Unable to geocode"},
+	},
+	"Lookup": {
+		tag:     "lookup",
+		formats: placeFormats,
+		extra:   polygonParams,
+		examples: map[string]string{
+			"":            `{"results":[` + examplePlace + `]}`,
+			"json":        `[` + exampleNominatimPlace("class", true) + `]`,
+			"jsonv2":      `[` + exampleNominatimPlace("category", true) + `]`,
+			"geojson":     exampleGeoJSON,
+			"geocodejson": exampleGeocodeJSON,
+			"xml": `<searchresults>
+  <result place_id="159349938" osm_type="relation" osm_id="62422" display_name="Berlin, Deutschland" class="boundary" type="administrative" importance="0.8544" lat="52.5173885" lon="13.3951309" boundingbox="52.3382448,52.6755087,13.088345,13.7611609"></result>
+</searchresults>`,
+		},
+	},
+	"Details": {
+		tag:     "details",
+		formats: []outputFormat{formatDefault, {name: "json", contentType: "application/json", schema: ref("NominatimDetails"), summary: "format=json：Nominatim 详情"}, {name: "jsonv2", contentType: "application/json", schema: ref("NominatimDetails"), summary: "format=jsonv2：同 json"}},
+		examples: map[string]string{
+			"":       `{"result":{"placeId":"159349938","licence":"Data © OpenStreetMap contributors","osmId":"62422","osmType":"relation","category":"boundary","type":"administrative","importance":0.8544,"displayName":"Berlin, Deutschland","centroid":{"lat":52.5173885,"lon":13.3951309},"boundingbox":null,"icon":"","extratags":{"wikidata":"Q64"},"namedetails":{},"addressRows":[],"polygonGeojson":"","address":{},"name":"Berlin","placeRank":8,"addresstype":"city"},"parentPlaceId":"0","linkedPlaceId":"0","adminLevel":4,"rankAddress":8,"rankSearch":8,"indexedDate":"2024-05-01T10:00:00+00:00","names":{"name":"Berlin","name:en":"Berlin"},"addresstags":{},"housenumber":"","calculatedPostcode":"","countryCode":"de","calculatedImportance":0.8544,"calculatedWikipedia":"de:Berlin","isarea":true,"address":[],"linkedPlaces":[],"keywords":null,"localname":"Berlin"}`,
+			"json":   exampleDetails,
+			"jsonv2": exampleDetails,
+		},
+		notFound: [2]string{"对象不存在", "No place with that OSM ID found."},
+	},
+	"Status": {
+		tag:      "status",
+		formats:  []outputFormat{formatDefault},
+		examples: map[string]string{"": `{"version":"dev","dbStatus":"ok","uptime":"3h12m5s"}`},
+	},
+	"BatchSearch":  {tag: "batch", formats: []outputFormat{formatDefault}},
+	"BatchReverse": {tag: "batch", formats: []outputFormat{formatDefault}},
+	"Deletable": {
+		tag:     "maintenance",
+		formats: []outputFormat{formatDefault, {name: "json", contentType: "application/json", schema: &schema{Type: "array", Items: &schema{Type: "object"}}, summary: "format=json：对象数组"}, formatHTML, formatText},
+		examples: map[string]string{
+			"":     `{"placeIds":["127761056"],"objects":[{"placeId":"127761056","countryCode":"de","name":"Alter Park","osmId":"4365848","osmType":"W","class":"leisure","type":"park"}]}`,
+			"json": `[{"place_id":127761056,"country_code":"de","name":"Alter Park","osm_id":4365848,"osm_type":"W","class":"leisure","type":"park"}]`,
+			"html": "<!DOCTYPE html>\n<html><head><meta charset=\"utf-8\"><title>Deletable objects</title></head><body>\n<h1>Deletable objects</h1>\n<p>1 objects</p>\n<table>\n<tr><th>place_id</th><th>country_code</th><th>name</th><th>osm_type</th><th>osm_id</th><th>class</th><th>type</th></tr>\n<tr><td>127761056</td><td>de</td><td>Alter Park</td><td>W</td><td>4365848</td><td>leisure</td><td>park</td></tr>\n</table>\n</body></html>\n",
+			"text": "place_id\tcountry_code\tname\tosm_type\tosm_id\tclass\ttype\n127761056\tde\tAlter Park\tW\t4365848\tleisure\tpark\n",
+		},
+	},
+	"Polygons": {
+		tag:     "maintenance",
+		formats: []outputFormat{formatDefault, {name: "json", contentType: "application/json", schema: &schema{Type: "array", Items: &schema{Type: "object"}}, summary: "format=json：对象数组"}, formatHTML, formatText},
+		examples: map[string]string{
+			"":     `{"placeIds":[],"polygons":[{"osmType":"R","osmId":"2313720","class":"boundary","type":"administrative","name":"Gemeinde X","countryCode":"de","errormessage":"Self-intersection","updated":"2024-05-01 10:00:00","errorLocation":{"lat":52.1,"lon":13.2}}]}`,
+			"json": `[{"osm_type":"R","osm_id":2313720,"class":"boundary","type":"administrative","name":"Gemeinde X","country_code":"de","errormessage":"Self-intersection","error_location":{"lat":52.1,"lon":13.2},"updated":"2024-05-01 10:00:00"}]`,
+			"html": "<!DOCTYPE html>\n<html><head><meta charset=\"utf-8\"><title>Broken polygons</title></head><body>\n<h1>Broken polygons</h1>\n<p>1 objects</p>\n<table>\n<tr><th>osm_type</th><th>osm_id</th><th>class</th><th>type</th><th>name</th><th>country_code</th><th>errormessage</th><th>error_location</th><th>updated</th></tr>\n<tr><td>R</td><td>2313720</td><td>boundary</td><td>administrative</td><td>Gemeinde X</td><td>de</td><td>Self-intersection</td><td>52.1000000,13.2000000</td><td>2024-05-01 10:00:00</td></tr>\n</table>\n</body></html>\n",
+			"text": "osm_type\tosm_id\tclass\ttype\tname\tcountry_code\terrormessage\terror_location\tupdated\nR\t2313720\tboundary\tadministrative\tGemeinde X\tde\tSelf-intersection\t52.1000000,13.2000000\t2024-05-01 10:00:00\n",
+		},
+	},
+}
+
+// 示例数据：柏林（relation 62422）。
+const (
+	examplePlace       = `{"placeId":"159349938","licence":"Data © OpenStreetMap contributors","osmId":"62422","osmType":"relation","category":"boundary","type":"administrative","importance":0.8544,"displayName":"Berlin, Deutschland","centroid":{"lat":52.5173885,"lon":13.3951309},"boundingbox":{"south":52.3382448,"north":52.6755087,"west":13.088345,"east":13.7611609},"icon":"","extratags":{},"namedetails":{},"addressRows":[],"polygonGeojson":"","address":{"ISO3166-2-lvl4":"DE-BE","city":"Berlin","country":"Deutschland","country_code":"de"},"name":"Berlin","placeRank":8,"addresstype":"city"}`
+	exampleGeoJSON     = `{"type":"FeatureCollection","features":[{"type":"Feature","properties":{"address":{"ISO3166-2-lvl4":"DE-BE","city":"Berlin","country":"Deutschland","country_code":"de"},"category":"boundary","display_name":"Berlin, Deutschland","importance":0.8544,"osm_id":"62422","osm_type":"relation","place_id":159349938,"type":"administrative"},"bbox":[13.088345,52.3382448,13.7611609,52.6755087],"geometry":{"coordinates":[13.3951309,52.5173885],"type":"Point"}}]}`
+	exampleGeocodeJSON = `{"type":"FeatureCollection","geocoding":{"version":"0.1.0"},"features":[{"geometry":{"coordinates":[13.3951309,52.5173885],"type":"Point"},"properties":{"geocoding":{"label":"Berlin, Deutschland","name":"Berlin, Deutschland","type":"administrative"}},"type":"Feature"}]}`
+	exampleDetails     = `{"place_id":159349938,"parent_place_id":0,"linked_place_id":0,"osm_type":"R","osm_id":62422,"category":"boundary","type":"administrative","admin_level":4,"localname":"Berlin","names":{"name":"Berlin","name:en":"Berlin"},"addresstags":{},"housenumber":null,"calculated_postcode":null,"country_code":"de","indexed_date":"2024-05-01T10:00:00+00:00","importance":0.8544,"calculated_importance":0.8544,"extratags":{"wikidata":"Q64"},"calculated_wikipedia":"de:Berlin","rank_address":8,"rank_search":8,"isarea":true,"centroid":{"type":"Point","coordinates":[13.3951309,52.5173885]},"geometry":{"type":"Point","coordinates":[13.3951309,52.5173885]}}`
+)
+
+// exampleNominatimPlace json/jsonv2 的单个结果；classLabel 为类别字段名，address 表示 addressdetails=1。
+func exampleNominatimPlace(classLabel string, address bool) string {
+	s := `{"place_id":159349938,"licence":"Data © OpenStreetMap contributors","osm_type":"relation","osm_id":62422,"lat":"52.5173885","lon":"13.3951309","` +
+		classLabel + `":"boundary","type":"administrative","place_rank":8,"importance":0.8544,"addresstype":"city","name":"Berlin","display_name":"Berlin, Deutschland",`
+	if address {
+		s += `"address":{"city":"Berlin","ISO3166-2-lvl4":"DE-BE","country":"Deutschland","country_code":"de"},`
+	}
+	return s + `"boundingbox":["52.3382448","52.6755087","13.0883450","13.7611609"]}`
+}
+
+// outputSchemas 各输出格式的结构（components.schemas）。
+func outputSchemas() map[string]*schema {
+	str := &schema{Type: "string"}
+	strMap := &schema{Type: "object", AdditionalProperties: str}
+	num := &schema{Type: "number"}
+	integer := &schema{Type: "integer", Format: "int64"}
+	point := &schema{Type: "object", Properties: map[string]*schema{
+		"type":        {Type: "string", Enum: []any{"Point"}},
+		"coordinates": {Type: "array", Items: num, MinItems: uint64Ptr(2), MaxItems: uint64Ptr(2)},
+	}}
+	geometry := &schema{Type: "object", Description: "GeoJSON 几何（polygon_geojson=1 时为多边形，否则为质心点）", Properties: map[string]*schema{
+		"type":        str,
+		"coordinates": {Type: "array", Items: &schema{}},
+	}}
+	return map[string]*schema{
+		"NominatimPlace": {Type: "object", Description: "format=json/jsonv2 的单个结果（search/lookup 为数组，reverse 为单个对象）", Properties: map[string]*schema{
+			"place_id":     integer,
+			"licence":      str,
+			"osm_type":     {Type: "string", Enum: []any{"node", "way", "relation"}},
+			"osm_id":       integer,
+			"lat":          {Type: "string", Description: "纬度（字符串）"},
+			"lon":          {Type: "string", Description: "经度（字符串）"},
+			"class":        {Type: "string", Description: "类别（format=json）"},
+			"category":     {Type: "string", Description: "类别（format=jsonv2）"},
+			"type":         str,
+			"place_rank":   {Type: "integer"},
+			"importance":   num,
+			"addresstype":  str,
+			"name":         str,
+			"display_name": str,
+			"address":      {Type: "object", Description: "addressdetails=1 时返回，键由具体到宽泛排列", AdditionalProperties: str},
+			"extratags":    {Type: "object", Description: "extratags=1 时返回", AdditionalProperties: str},
+			"namedetails":  {Type: "object", Description: "namedetails=1 时返回", AdditionalProperties: str},
+			"boundingbox":  {Type: "array", Description: "[south, north, west, east]（字符串）", Items: str, MinItems: uint64Ptr(4), MaxItems: uint64Ptr(4)},
+			"geojson":      {Type: "object", Description: "polygon_geojson=1 时返回"},
+			"geotext":      {Type: "string", Description: "polygon_text=1 时返回"},
+			"svg":          {Type: "string", Description: "polygon_svg=1 时返回"},
+			"geokml":       {Type: "string", Description: "polygon_kml=1 时返回"},
+		}},
+		"NominatimPlaceList": {Type: "array", Items: ref("NominatimPlace")},
+		"NominatimReverseMiss": {Type: "object", Description: "指定 format 时 /reverse 无结果（200）", Properties: map[string]*schema{
+			"error": {Type: "string", Enum: []any{"Unable to geocode"}},
+		}},
+		"NominatimDetails": {Type: "object", Description: "format=json/jsonv2 的 /details 结果", Properties: map[string]*schema{
+			"place_id":              integer,
+			"parent_place_id":       integer,
+			"linked_place_id":       integer,
+			"osm_type":              {Type: "string", Enum: []any{"N", "W", "R"}},
+			"osm_id":                integer,
+			"category":              str,
+			"type":                  str,
+			"admin_level":           {Type: "integer"},
+			"localname":             str,
+			"names":                 strMap,
+			"addresstags":           strMap,
+			"housenumber":           {Type: "string", Nullable: true},
+			"calculated_postcode":   {Type: "string", Nullable: true},
+			"country_code":          {Type: "string", Nullable: true},
+			"indexed_date":          str,
+			"importance":            num,
+			"calculated_importance": num,
+			"extratags":             strMap,
+			"calculated_wikipedia":  {Type: "string", Nullable: true},
+			"rank_address":          {Type: "integer"},
+			"rank_search":           {Type: "integer"},
+			"isarea":                {Type: "boolean"},
+			"centroid":              point,
+			"geometry":              geometry,
+			"address":               {Type: "array", Description: "addressdetails=1 时返回", Items: &schema{Type: "object"}},
+			"linked_places":         {Type: "array", Description: "linkedplaces=1 时返回", Items: &schema{Type: "object"}},
+			"keywords":              {Type: "object", Description: "keywords=1 时返回"},
+		}},
+		"GeoJSONFeatureCollection": {Type: "object", Properties: map[string]*schema{
+			"type":    {Type: "string", Enum: []any{"FeatureCollection"}},
+			"licence": str,
+			"features": {Type: "array", Items: &schema{Type: "object", Properties: map[string]*schema{
+				"type":       {Type: "string", Enum: []any{"Feature"}},
+				"properties": {Type: "object", Description: "place_id、osm_type、osm_id、category、type、display_name、importance、address 等"},
+				"bbox":       {Type: "array", Description: "[west, south, east, north]", Items: num},
+				"geometry":   geometry,
+			}}},
+		}},
+		"GeocodeJSONFeatureCollection": {Type: "object", Properties: map[string]*schema{
+			"type":      {Type: "string", Enum: []any{"FeatureCollection"}},
+			"geocoding": {Type: "object", Properties: map[string]*schema{"version": str}},
+			"features": {Type: "array", Items: &schema{Type: "object", Properties: map[string]*schema{
+				"type":       {Type: "string", Enum: []any{"Feature"}},
+				"properties": {Type: "object", Properties: map[string]*schema{"geocoding": {Type: "object", Properties: map[string]*schema{"type": str, "label": str, "name": str}}}},
+				"geometry":   geometry,
+			}}},
+		}},
+		"Error": {Type: "object", Description: "未指定 format 时的错误体（Kratos）", Properties: map[string]*schema{
+			"code":     {Type: "integer"},
+			"reason":   {Type: "string", Description: "BAD_REQUEST/NOT_FOUND/TIMEOUT/UNAVAILABLE/RATE_LIMIT 等"},
+			"message":  str,
+			"metadata": {Type: "object", Description: "参数校验失败时为 字段路径 -> 违规说明", AdditionalProperties: str},
+		}},
+		"NominatimError": {Type: "object", Description: "指定 format 时的错误体（xml 为 <error><code/><message/></error>）", Properties: map[string]*schema{
+			"error": {Type: "object", Properties: map[string]*schema{"code": {Type: "integer"}, "message": str}},
+		}},
+	}
+}
+
+// errorResponses 各路由共有的错误响应。
+func errorResponses(notFound [2]string) map[string]*response {
+	errContent := func(code int, message string) map[string]*mediaType {
+		return map[string]*mediaType{"application/json": {
+			Schema: &schema{OneOf: []*schema{ref("Error"), ref("NominatimError")}},
+			Examples: map[string]*example{
+				"default": {Summary: "未指定 format", Value: map[string]any{"code": code, "reason": reasonOf(code), "message": message, "metadata": map[string]string{}}},
+				"json":    {Summary: "指定 format（json/jsonv2/geojson/geocodejson）", Value: map[string]any{"error": map[string]any{"code": code, "message": message}}},
+			},
+		}}
+	}
+	out := map[string]*response{
+		"400": {Description: "参数不合法（解析或 buf.validate 校验失败）", Content: errContent(400, "Invalid parameter 'lat': value must be greater than or equal to -90 and less than or equal to 90.")},
+		"429": {Description: "超出限流（带 Retry-After 与 X-RateLimit-* 响应头）", Content: errContent(429, "rate limit exceeded")},
+		"500": {Description: "内部错误（不包含驱动信息）", Content: errContent(500, "internal server error")},
+		"503": {Description: "数据库不可用", Content: errContent(503, "Database unavailable.")},
+		"504": {Description: "查询超时", Content: errContent(504, "Query took too long to process.")},
+	}
+	if notFound[0] != "" {
+		out["404"] = &response{Description: notFound[0], Content: errContent(404, notFound[1])}
+	}
+	return out
+}
+
+func reasonOf(code int) string {
+	switch code {
+	case 400:
+		return "BAD_REQUEST"
+	case 404:
+		return "NOT_FOUND"
+	case 429:
+		return "RATE_LIMIT"
+	case 503:
+		return "UNAVAILABLE"
+	case 504:
+		return "TIMEOUT"
+	}
+	return "INTERNAL_SERVER"
+}
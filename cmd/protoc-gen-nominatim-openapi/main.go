@@ -0,0 +1,424 @@
+// protoc-gen-nominatim-openapi 由 nominatim.proto 生成 OpenAPI 3 文档（buf.gen.openapi.yaml，输出 internal/server/openapi/openapi.json）。
+//
+// 路径来自 google.api.http 注解；GET 路由的查询参数取自请求消息字段：参数名按 Nominatim 写法（accept-language、featureType、osmid），
+// 说明取自 proto 注释，取值范围与格式取自 buf.validate 规则，列表为逗号分隔、布尔值为 0/1（与 internal/server/decoder.go 一致）。
+// 各路由的 format 取值、响应结构与示例见 formats.go。
+package main
+
+import (
+	"encoding/json"
+	"strings"
+
+	"buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+// queryNames 与 proto 字段名不同的 Nominatim 参数名（解析时键名不区分连字符/下划线/大小写，两种写法均可）。
+var queryNames = map[protoreflect.FullName]string{
+	"nominatim.v1.SearchRequest.accept_language":  "accept-language",
+	"nominatim.v1.SearchRequest.featuretype":      "featureType",
+	"nominatim.v1.ReverseRequest.accept_language": "accept-language",
+	"nominatim.v1.LookupRequest.accept_language":  "accept-language",
+	"nominatim.v1.DetailsRequest.accept_language": "accept-language",
+	"nominatim.v1.DetailsRequest.osm_id":          "osmid",
+}
+
+func main() {
+	protogen.Options{}.Run(func(gen *protogen.Plugin) error {
+		gen.SupportedFeatures = uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL)
+		b := &builder{
+			doc: &document{
+				OpenAPI: "3.0.3",
+				Info: info{
+					Title:       "nominatim-go",
+					Description: "Nominatim v1 兼容的地理编码 API。查询参数键名不区分连字符/下划线/大小写，列表以逗号分隔，布尔值为 0/1；响应格式由 format 参数选择。",
+					Version:     "v1",
+				},
+				Servers: []server{{URL: "/"}},
+				Tags: []tag{
+					{Name: "search", Description: "名称/地址搜索"},
+					{Name: "reverse", Description: "逆地理编码"},
+					{Name: "lookup", Description: "按 OSM ID 查询"},
+					{Name: "details", Description: "对象详情"},
+					{Name: "status", Description: "服务状态"},
+					{Name: "batch", Description: "批量请求"},
+					{Name: "maintenance", Description: "维护端点"},
+				},
+				Paths:      map[string]*pathItem{},
+				Components: components{Schemas: outputSchemas()},
+			},
+		}
+		for _, f := range gen.Files {
+			if !f.Generate {
+				continue
+			}
+			for _, svc := range f.Services {
+				for _, m := range svc.Methods {
+					b.addMethod(m)
+				}
+			}
+		}
+		out, err := json.MarshalIndent(b.doc, "", "  ")
+		if err != nil {
+			return err
+		}
+		g := gen.NewGeneratedFile("openapi.json", "")
+		_, err = g.Write(append(out, '\n'))
+		return err
+	})
+}
+
+type builder struct {
+	doc *document
+}
+
+func (b *builder) addMethod(m *protogen.Method) {
+	rule, _ := proto.GetExtension(m.Desc.Options(), annotations.E_Http).(*annotations.HttpRule)
+	if rule == nil {
+		return
+	}
+	rt, ok := routes[string(m.Desc.Name())]
+	if !ok {
+		rt = route{formats: []outputFormat{formatDefault}}
+	}
+	summary, desc := splitComment(m.Comments.Leading)
+	op := &operation{
+		Summary:     summary,
+		Description: desc,
+		OperationID: string(m.Desc.Name()),
+		Responses:   errorResponses(rt.notFound),
+	}
+	if rt.tag != "" {
+		op.Tags = []string{rt.tag}
+	}
+	item := &pathItem{}
+	var path string
+	switch {
+	case rule.GetGet() != "":
+		path, item.Get = rule.GetGet(), op
+		op.Parameters = b.queryParams(m.Input)
+		if len(rt.formats) > 1 {
+			op.Parameters = append(op.Parameters, formatParam(rt.formats))
+		}
+		op.Parameters = append(op.Parameters, rt.extra...)
+	case rule.GetPost() != "":
+		path, item.Post = rule.GetPost(), op
+		op.RequestBody = &requestBody{Required: true, Content: map[string]*mediaType{
+			"application/json": {Schema: b.messageRef(m.Input)},
+		}}
+	default:
+		return
+	}
+	op.Responses["200"] = b.okResponse(m, rt)
+	if existing, ok := b.doc.Paths[path]; ok {
+		if item.Get != nil {
+			existing.Get = item.Get
+		}
+		if item.Post != nil {
+			existing.Post = item.Post
+		}
+		return
+	}
+	b.doc.Paths[path] = item
+}
+
+// okResponse 200 响应：按内容类型合并各 format 的结构，示例以 format 取值为键。
+func (b *builder) okResponse(m *protogen.Method, rt route) *response {
+	content := map[string]*mediaType{}
+	for _, f := range rt.formats {
+		s := f.schema
+		if f.name == "" {
+			s = b.messageRef(m.Output)
+		}
+		mt, ok := content[f.contentType]
+		if !ok {
+			mt = &mediaType{Schema: s}
+			content[f.contentType] = mt
+		} else if !sameSchema(mt.Schema, s) {
+			if len(mt.Schema.OneOf) == 0 {
+				mt.Schema = &schema{OneOf: []*schema{mt.Schema}}
+			}
+			if !containsSchema(mt.Schema.OneOf, s) {
+				mt.Schema.OneOf = append(mt.Schema.OneOf, s)
+			}
+		}
+		ex, ok := rt.examples[f.name]
+		if !ok {
+			continue
+		}
+		if mt.Examples == nil {
+			mt.Examples = map[string]*example{}
+		}
+		key := f.name
+		if key == "" {
+			key = "default"
+		}
+		mt.Examples[key] = &example{Summary: f.summary, Value: exampleValue(f.contentType, ex)}
+	}
+	if ex, ok := rt.examples["miss"]; ok {
+		if mt := content["application/json"]; mt != nil {
+			mt.Examples["miss"] = &example{Summary: "指定 format 且无结果", Value: exampleValue("application/json", ex)}
+			mt.Schema.OneOf = append(mt.Schema.OneOf, ref("NominatimReverseMiss"))
+		}
+	}
+	return &response{Description: "成功", Content: content}
+}
+
+func exampleValue(contentType, s string) any {
+	if contentType == "application/json" {
+		return rawJSON(s)
+	}
+	return s
+}
+
+func sameSchema(a, b *schema) bool {
+	return a == b || (a.Ref != "" && a.Ref == b.Ref)
+}
+
+func containsSchema(list []*schema, s *schema) bool {
+	for _, v := range list {
+		if sameSchema(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// queryParams 请求消息的字段作为查询参数（map 与无法以查询参数表达的嵌套消息除外）。
+func (b *builder) queryParams(msg *protogen.Message) []*parameter {
+	var out []*parameter
+	for _, f := range msg.Fields {
+		if p := queryParam(f); p != nil {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+func queryParam(f *protogen.Field) *parameter {
+	fd := f.Desc
+	name := string(fd.Name())
+	if n, ok := queryNames[fd.FullName()]; ok {
+		name = n
+	}
+	p := &parameter{Name: name, In: "query", Description: commentText(f.Comments.Leading)}
+	switch {
+	case fd.IsMap():
+		return nil
+	case fd.Kind() == protoreflect.MessageKind:
+		switch fd.Message().FullName() {
+		case "nominatim.v1.ViewBox":
+			p.Schema = &schema{Type: "string", Pattern: `^\s*-?[0-9.]+\s*,\s*-?[0-9.]+\s*,\s*-?[0-9.]+\s*,\s*-?[0-9.]+\s*$`}
+			p.Description += "（x1,y1,x2,y2：任意两个对角的经度、纬度）"
+			p.Example = "13.08,52.33,13.76,52.68"
+		case "nominatim.v1.Locales":
+			p.Schema = &schema{Type: "array", Items: &schema{Type: "string"}}
+			p.Style, p.Explode = "form", boolPtr(false)
+		default:
+			return nil
+		}
+		return p
+	case fd.IsList():
+		p.Schema = &schema{Type: "array", Items: scalarSchema(fd, true)}
+		p.Style, p.Explode = "form", boolPtr(false)
+	default:
+		p.Schema = scalarSchema(fd, true)
+	}
+	if r, _ := proto.GetExtension(fd.Options(), validate.E_Field).(*validate.FieldRules); r != nil {
+		applyFieldRules(p.Schema, r)
+		p.Required = r.GetRequired() || (p.Schema.MinItems != nil && *p.Schema.MinItems > 0)
+	}
+	return p
+}
+
+// scalarSchema 标量字段的结构；query 为真时按查询参数语法（布尔为 0/1、int64 为整数）。
+func scalarSchema(fd protoreflect.FieldDescriptor, query bool) *schema {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		if query {
+			return &schema{Type: "integer", Enum: []any{0, 1}}
+		}
+		return &schema{Type: "boolean"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return &schema{Type: "integer", Format: "int32"}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return &schema{Type: "integer", Format: "int32", Minimum: float64Ptr(0)}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		if query {
+			return &schema{Type: "integer", Format: "int64"}
+		}
+		// protojson 以字符串输出 64 位整数
+		return &schema{Type: "string", Format: "int64"}
+	case protoreflect.FloatKind:
+		return &schema{Type: "number", Format: "float"}
+	case protoreflect.DoubleKind:
+		return &schema{Type: "number", Format: "double"}
+	case protoreflect.BytesKind:
+		return &schema{Type: "string", Format: "byte"}
+	case protoreflect.EnumKind:
+		s := &schema{Type: "string"}
+		values := fd.Enum().Values()
+		for i := 0; i < values.Len(); i++ {
+			s.Enum = append(s.Enum, string(values.Get(i).Name()))
+		}
+		return s
+	}
+	return &schema{Type: "string"}
+}
+
+// messageRef 消息结构的引用（protojson 形式），首次引用时加入 components.schemas。
+func (b *builder) messageRef(msg *protogen.Message) *schema {
+	switch msg.Desc.FullName() {
+	case "google.protobuf.Empty":
+		return &schema{Type: "object"}
+	case "google.protobuf.Timestamp", "google.protobuf.Duration":
+		return &schema{Type: "string"}
+	}
+	name := string(msg.Desc.Name())
+	if _, ok := b.doc.Components.Schemas[name]; ok {
+		return ref(name)
+	}
+	s := &schema{Type: "object", Description: commentText(msg.Comments.Leading), Properties: map[string]*schema{}}
+	b.doc.Components.Schemas[name] = s
+	for _, f := range msg.Fields {
+		fs := b.fieldSchema(f)
+		if d := commentText(f.Comments.Leading); d != "" && fs.Ref == "" {
+			fs.Description = d
+		}
+		s.Properties[f.Desc.JSONName()] = fs
+	}
+	return ref(name)
+}
+
+func (b *builder) fieldSchema(f *protogen.Field) *schema {
+	fd := f.Desc
+	var item *schema
+	switch {
+	case fd.IsMap():
+		return &schema{Type: "object", AdditionalProperties: scalarSchema(fd.MapValue(), false)}
+	case fd.Kind() == protoreflect.MessageKind:
+		item = b.messageRef(f.Message)
+	default:
+		item = scalarSchema(fd, false)
+	}
+	s := item
+	if fd.IsList() {
+		s = &schema{Type: "array", Items: item}
+	}
+	if r, _ := proto.GetExtension(fd.Options(), validate.E_Field).(*validate.FieldRules); r != nil && s.Ref == "" {
+		applyFieldRules(s, r)
+	}
+	return s
+}
+
+// applyFieldRules 将 buf.validate 规则映射为结构约束（数值范围、正则、长度、枚举、条数）。
+func applyFieldRules(s *schema, r *validate.FieldRules) {
+	rm := r.ProtoReflect()
+	od := rm.Descriptor().Oneofs().ByName("type")
+	if od == nil {
+		return
+	}
+	fd := rm.WhichOneof(od)
+	if fd == nil {
+		return
+	}
+	rules := rm.Get(fd).Message()
+	if rep := r.GetRepeated(); rep != nil {
+		if rep.HasMinItems() {
+			s.MinItems = uint64Ptr(rep.GetMinItems())
+		}
+		if rep.HasMaxItems() {
+			s.MaxItems = uint64Ptr(rep.GetMaxItems())
+		}
+		if items := rep.GetItems(); items != nil && s.Items != nil {
+			applyFieldRules(s.Items, items)
+		}
+		return
+	}
+	rules.Range(func(f protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch f.Name() {
+		case "gte", "gt":
+			s.Minimum, s.ExclusiveMinimum = float64Ptr(toFloat(v)), f.Name() == "gt"
+		case "lte", "lt":
+			s.Maximum, s.ExclusiveMaximum = float64Ptr(toFloat(v)), f.Name() == "lt"
+		case "pattern":
+			s.Pattern = v.String()
+		case "min_len":
+			s.MinLength = uint64Ptr(v.Uint())
+		case "max_len":
+			s.MaxLength = uint64Ptr(v.Uint())
+		case "in":
+			list := v.List()
+			s.Enum = nil
+			for i := 0; i < list.Len(); i++ {
+				s.Enum = append(s.Enum, list.Get(i).Interface())
+			}
+		}
+		return true
+	})
+}
+
+func toFloat(v protoreflect.Value) float64 {
+	switch n := v.Interface().(type) {
+	case int32:
+		return float64(n)
+	case int64:
+		return float64(n)
+	case uint32:
+		return float64(n)
+	case uint64:
+		return float64(n)
+	case float32:
+		return float64(n)
+	case float64:
+		return n
+	}
+	return 0
+}
+
+// formatParam format 参数（取值为该路由支持的格式）。
+func formatParam(formats []outputFormat) *parameter {
+	s := &schema{Type: "string"}
+	var names []string
+	for _, f := range formats {
+		if f.name != "" {
+			s.Enum = append(s.Enum, f.name)
+			names = append(names, f.name)
+		}
+	}
+	return &parameter{
+		Name:        "format",
+		In:          "query",
+		Description: "输出格式（" + strings.Join(names, "/") + "）；省略时为 protojson",
+		Schema:      s,
+	}
+}
+
+func flagParam(name, desc string) *parameter {
+	return &parameter{Name: name, In: "query", Description: desc, Schema: &schema{Type: "integer", Enum: []any{0, 1}}}
+}
+
+// commentText proto 注释的纯文本（多行以换行连接）。
+func commentText(c protogen.Comments) string {
+	lines := strings.Split(strings.TrimSpace(string(c)), "\n")
+	for i, l := range lines {
+		lines[i] = strings.TrimSpace(l)
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// splitComment 注释首行作为摘要，其余作为说明。
+func splitComment(c protogen.Comments) (string, string) {
+	text := commentText(c)
+	summary, rest, _ := strings.Cut(text, "\n")
+	return summary, strings.TrimSpace(rest)
+}
+
+func boolPtr(v bool) *bool          { return &v }
+func float64Ptr(v float64) *float64 { return &v }
+func uint64Ptr(v uint64) *uint64    { return &v }
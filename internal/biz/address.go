@@ -0,0 +1,149 @@
+package biz
+
+import (
+	"strconv"
+	"strings"
+
+	"nominatim-go/pkg/locale"
+)
+
+// PostcodeRank 邮编在地址层级中的等级（介于州与国家之间）。
+const PostcodeRank = 5
+
+// AddressPart 地址对象的一个键值（键为 Nominatim addresstype 标签）。
+type AddressPart struct {
+	Key   string
+	Value string
+}
+
+// adminLabels 对齐 Nominatim ADMIN_LABELS：国家代码 -> (rank_address/2) -> 标签；空国家代码为通用表。
+var adminLabels = map[string]map[int]string{
+	"": {
+		1:  "Continent",
+		2:  "Country",
+		3:  "Region",
+		4:  "State",
+		5:  "State District",
+		6:  "County",
+		7:  "Municipality",
+		8:  "City",
+		9:  "City District",
+		10: "Suburb",
+		11: "Neighbourhood",
+		12: "City Block",
+	},
+	"no": {3: "State", 4: "County"},
+	"se": {3: "State", 4: "County"},
+}
+
+// LabelTag 对齐 Nominatim get_label_tag：根据类别、extratags、地址等级与国家代码确定地址键。
+func LabelTag(category, typ string, extratags map[string]string, rank int, countryCode string) string {
+	var label string
+	switch {
+	case rank < 26 && extratags["place"] != "":
+		label = extratags["place"]
+	case rank < 26 && extratags["linked_place"] != "":
+		label = extratags["linked_place"]
+	case category == "boundary" && typ == "administrative":
+		label = adminLabels[strings.ToLower(countryCode)][rank/2]
+		if label == "" {
+			label = adminLabels[""][rank/2]
+		}
+		if label == "" {
+			label = "Administrative"
+		}
+	case typ == "postal_code":
+		label = "postcode"
+	case rank < 26:
+		label = typ
+		if typ == "yes" {
+			label = category
+		}
+	case rank < 28:
+		label = "road"
+	case category == "place" && (typ == "house_number" || typ == "house_name" || typ == "country_code"):
+		label = typ
+	default:
+		label = category
+	}
+	return strings.ReplaceAll(strings.ToLower(label), " ", "_")
+}
+
+// DisplayName 由地址层级拼接展示名称（由具体到宽泛，逗号分隔）：
+// 对象自身名称、门牌号、isaddress 地址行（邮编按等级插入）；地址行使用已本地化的 LocalName。
+func DisplayName(it *SearchPlace, langs locale.Locales) string {
+	var parts []string
+	add := func(s string) {
+		s = strings.TrimSpace(s)
+		if s == "" || (len(parts) > 0 && parts[len(parts)-1] == s) {
+			return
+		}
+		parts = append(parts, s)
+	}
+	name := langs.Name(it.NameDetails)
+	if name == "" {
+		name = it.Name
+	}
+	add(name)
+	add(it.HouseNumber)
+	postcode := strings.TrimSpace(it.Postcode)
+	if postcode == name {
+		postcode = ""
+	}
+	for _, r := range it.AddressRows {
+		if !r.IsAddress {
+			continue
+		}
+		if postcode != "" && r.Rank < PostcodeRank {
+			add(postcode)
+			postcode = ""
+		}
+		label := r.LocalName
+		if postcode != "" && label == postcode {
+			postcode = ""
+		}
+		add(label)
+	}
+	add(postcode)
+	return strings.Join(parts, ", ")
+}
+
+// AddressParts 构造 addressdetails 的地址对象（由具体到宽泛）：
+// 对象自身、门牌号、isaddress 地址行（含 ISO3166-2-lvlN）、邮编与 country_code；同名键保留首个。
+func AddressParts(it *SearchPlace, langs locale.Locales) []AddressPart {
+	var parts []AddressPart
+	seen := map[string]struct{}{}
+	add := func(key, value string) {
+		value = strings.TrimSpace(value)
+		if key == "" || value == "" {
+			return
+		}
+		if _, ok := seen[key]; ok {
+			return
+		}
+		seen[key] = struct{}{}
+		parts = append(parts, AddressPart{Key: key, Value: value})
+	}
+	if name := langs.Name(it.NameDetails); name != "" {
+		add(LabelTag(it.Category, it.Type, it.ExtraTags, it.RankAddress, it.CountryCode), name)
+	}
+	add("house_number", it.HouseNumber)
+	postcode := it.Postcode
+	for _, r := range it.AddressRows {
+		if !r.IsAddress {
+			continue
+		}
+		if postcode != "" && r.Rank < PostcodeRank {
+			add("postcode", postcode)
+			postcode = ""
+		}
+		label := r.LocalName
+		add(r.Component, label)
+		if iso := r.Names["ISO3166-2"]; iso != "" && r.AdminLevel > 0 {
+			add("ISO3166-2-lvl"+strconv.Itoa(int(r.AdminLevel)), iso)
+		}
+	}
+	add("postcode", postcode)
+	add("country_code", strings.ToLower(it.CountryCode))
+	return parts
+}
@@ -0,0 +1,164 @@
+package biz
+
+import (
+	"reflect"
+	"testing"
+
+	"nominatim-go/pkg/locale"
+)
+
+func TestLabelTag(t *testing.T) {
+	tests := []struct {
+		name      string
+		category  string
+		typ       string
+		extratags map[string]string
+		rank      int
+		country   string
+		want      string
+	}{
+		{name: "place override", category: "boundary", typ: "administrative", extratags: map[string]string{"place": "City"}, rank: 16, want: "city"},
+		{name: "place override ignored for streets", category: "highway", typ: "residential", extratags: map[string]string{"place": "city"}, rank: 26, want: "road"},
+		{name: "linked place", category: "boundary", typ: "administrative", extratags: map[string]string{"linked_place": "Town"}, rank: 16, want: "town"},
+		{name: "place before linked place", category: "boundary", typ: "administrative", extratags: map[string]string{"place": "village", "linked_place": "town"}, rank: 16, want: "village"},
+		{name: "admin generic", category: "boundary", typ: "administrative", rank: 8, want: "state"},
+		{name: "admin lowercase and underscore", category: "boundary", typ: "administrative", rank: 10, want: "state_district"},
+		{name: "admin country table", category: "boundary", typ: "administrative", rank: 8, country: "NO", want: "county"},
+		{name: "admin country falls back to generic", category: "boundary", typ: "administrative", rank: 12, country: "se", want: "county"},
+		{name: "admin beyond table", category: "boundary", typ: "administrative", rank: 28, want: "administrative"},
+		{name: "postal code", category: "boundary", typ: "postal_code", rank: 21, want: "postcode"},
+		{name: "type yes uses category", category: "building", typ: "yes", rank: 20, want: "building"},
+		{name: "area type", category: "place", typ: "suburb", rank: 20, want: "suburb"},
+		{name: "street", category: "highway", typ: "primary", rank: 26, want: "road"},
+		{name: "house number", category: "place", typ: "house_number", rank: 30, want: "house_number"},
+		{name: "poi category", category: "amenity", typ: "cafe", rank: 30, want: "amenity"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LabelTag(tt.category, tt.typ, tt.extratags, tt.rank, tt.country); got != tt.want {
+				t.Fatalf("LabelTag = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// row 测试用地址行。
+func row(component, name string, rank uint32, isAddress bool) AddressRowItem {
+	return AddressRowItem{Component: component, LocalName: name, Rank: rank, IsAddress: isAddress}
+}
+
+func TestDisplayName(t *testing.T) {
+	mitte := []AddressRowItem{
+		row("road", "Pariser Platz", 26, true),
+		row("suburb", "Mitte", 18, true),
+		row("neighbourhood", "Friedrich-Wilhelm-Stadt", 22, false),
+		row("city", "Berlin", 16, true),
+		row("country", "Deutschland", 4, true),
+	}
+	tests := []struct {
+		name  string
+		langs string
+		it    *SearchPlace
+		want  string
+	}{
+		{
+			name: "postcode before country",
+			it:   &SearchPlace{Name: "Brandenburger Tor", Postcode: "10117", AddressRows: mitte},
+			want: "Brandenburger Tor, Pariser Platz, Mitte, Berlin, 10117, Deutschland",
+		},
+		{
+			name:  "localized own name",
+			langs: "en",
+			it:    &SearchPlace{Name: "Brandenburger Tor", NameDetails: map[string]string{"name": "Brandenburger Tor", "name:en": "Brandenburg Gate"}, AddressRows: mitte[3:]},
+			want:  "Brandenburg Gate, Berlin, Deutschland",
+		},
+		{
+			name: "house number after name",
+			it:   &SearchPlace{HouseNumber: "1", AddressRows: mitte[:1]},
+			want: "1, Pariser Platz",
+		},
+		{
+			name: "consecutive duplicates suppressed",
+			it: &SearchPlace{Name: "Berlin", AddressRows: []AddressRowItem{
+				row("city", "Berlin", 16, true), row("state", "Berlin", 8, true), row("country", "Deutschland", 4, true),
+			}},
+			want: "Berlin, Deutschland",
+		},
+		{
+			name: "postcode equal to name",
+			it:   &SearchPlace{Name: "10117", Postcode: "10117", AddressRows: mitte[3:]},
+			want: "10117, Berlin, Deutschland",
+		},
+		{
+			name: "postcode already in address rows",
+			it: &SearchPlace{Name: "Pariser Platz", Postcode: "10117", AddressRows: []AddressRowItem{
+				row("postcode", "10117", 11, true), row("city", "Berlin", 16, true), row("country", "Deutschland", 4, true),
+			}},
+			want: "Pariser Platz, 10117, Berlin, Deutschland",
+		},
+		{
+			name: "postcode appended without country",
+			it:   &SearchPlace{Name: "Pariser Platz", Postcode: "10117", AddressRows: mitte[3:4]},
+			want: "Pariser Platz, Berlin, 10117",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DisplayName(tt.it, locale.Parse(tt.langs)); got != tt.want {
+				t.Fatalf("DisplayName = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddressParts(t *testing.T) {
+	berlin := AddressRowItem{Component: "city", LocalName: "Berlin", Rank: 16, IsAddress: true, AdminLevel: 4, Names: map[string]string{"ISO3166-2": "DE-BE"}}
+	tests := []struct {
+		name string
+		it   *SearchPlace
+		want []AddressPart
+	}{
+		{
+			name: "poi with house number",
+			it: &SearchPlace{
+				Category: "amenity", Type: "cafe", RankAddress: 30, NameDetails: map[string]string{"name": "Café"},
+				HouseNumber: "1", Postcode: "10117", CountryCode: "DE",
+				AddressRows: []AddressRowItem{
+					row("road", "Pariser Platz", 26, true), row("suburb", "Mitte", 18, false), berlin, row("country", "Deutschland", 4, true),
+				},
+			},
+			want: []AddressPart{
+				{"amenity", "Café"}, {"house_number", "1"}, {"road", "Pariser Platz"}, {"city", "Berlin"},
+				{"ISO3166-2-lvl4", "DE-BE"}, {"postcode", "10117"}, {"country", "Deutschland"}, {"country_code", "de"},
+			},
+		},
+		{
+			name: "place override for own key",
+			it: &SearchPlace{
+				Category: "boundary", Type: "administrative", RankAddress: 16, ExtraTags: map[string]string{"place": "City"},
+				NameDetails: map[string]string{"name": "Berlin"}, CountryCode: "de",
+				AddressRows: []AddressRowItem{row("country", "Deutschland", 4, true)},
+			},
+			want: []AddressPart{{"city", "Berlin"}, {"country", "Deutschland"}, {"country_code", "de"}},
+		},
+		{
+			name: "first key wins",
+			it: &SearchPlace{
+				AddressRows: []AddressRowItem{row("city", "Mitte", 18, true), row("city", "Berlin", 16, true)},
+			},
+			want: []AddressPart{{"city", "Mitte"}},
+		},
+		{
+			name: "no own name",
+			it:   &SearchPlace{Category: "highway", Type: "primary", RankAddress: 26, Postcode: "10117"},
+			want: []AddressPart{{"postcode", "10117"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AddressParts(tt.it, nil); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("AddressParts = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
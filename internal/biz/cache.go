@@ -0,0 +1,146 @@
+package biz
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// 缓存的端点名（同时用于选择 TTL 与指标标签）
+const (
+	CacheEndpointSearch  = "search"
+	CacheEndpointReverse = "reverse"
+	CacheEndpointLookup  = "lookup"
+)
+
+// SearchCache 查询结果缓存，由 data 层实现（内存或 Redis，按端点配置 TTL）。
+// 缓存故障不应影响查询，实现方自行记录错误。
+type SearchCache interface {
+	Get(ctx context.Context, endpoint, key string) ([]byte, bool)
+	Set(ctx context.Context, endpoint, key string, val []byte)
+}
+
+// cacheRequests 缓存命中/未命中计数（经 /metrics 暴露）。
+var cacheRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "nominatim",
+	Name:      "cache_requests_total",
+	Help:      "Search usecase cache lookups by endpoint and result (hit/miss).",
+}, []string{"endpoint", "result"})
+
+// cacheLoadTimeout 合并后的查询的截止时间：查询与发起它的请求解绑，避免该请求取消/超时殃及合并到同一 key 的其他请求。
+const cacheLoadTimeout = 30 * time.Second
+
+// cached 先查缓存，未命中时经 singleflight 合并相同的并发查询，并回写缓存；未启用缓存时同样合并并发查询。
+// 合并的查询在独立的 context 中执行（保留 ctx 的值，不继承取消），每个调用方仅按自己的 ctx 放弃等待。
+func cached[T any](ctx context.Context, uc *SearchUsecase, endpoint string, params any, load func(ctx context.Context) (T, error)) (T, error) {
+	key := cacheKey(params)
+	if uc.cache != nil {
+		if b, ok := uc.cache.Get(ctx, endpoint, key); ok {
+			var v T
+			if err := json.Unmarshal(b, &v); err == nil {
+				cacheRequests.WithLabelValues(endpoint, "hit").Inc()
+				return v, nil
+			}
+		}
+		cacheRequests.WithLabelValues(endpoint, "miss").Inc()
+	}
+	ch := uc.group.DoChan(endpoint+":"+key, func() (any, error) {
+		lctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), cacheLoadTimeout)
+		defer cancel()
+		v, err := load(lctx)
+		if err != nil || uc.cache == nil {
+			return v, err
+		}
+		if b, err := json.Marshal(v); err == nil {
+			uc.cache.Set(lctx, endpoint, key, b)
+		}
+		return v, nil
+	})
+	var zero T
+	select {
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	case r := <-ch:
+		if r.Err != nil {
+			return zero, r.Err
+		}
+		return r.Val.(T), nil
+	}
+}
+
+// cacheKey 对归一化后的参数做摘要。
+func cacheKey(params any) string {
+	b, _ := json.Marshal(params)
+	sum := sha1.Sum(b)
+	return hex.EncodeToString(sum[:])
+}
+
+// normalizeKeyText 缓存键用：小写并压缩空白。
+func normalizeKeyText(s string) string {
+	return strings.Join(strings.Fields(strings.ToLower(s)), " ")
+}
+
+// normalizeKeyList 缓存键用：小写、去空、去重并排序。
+func normalizeKeyList(list []string) []string {
+	seen := map[string]struct{}{}
+	out := make([]string, 0, len(list))
+	for _, s := range list {
+		s = strings.ToLower(strings.TrimSpace(s))
+		if _, ok := seen[s]; ok || s == "" {
+			continue
+		}
+		seen[s] = struct{}{}
+		out = append(out, s)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// cacheParams 返回用于缓存键的归一化搜索参数。
+func (p SearchParams) cacheParams() SearchParams {
+	p.Q = normalizeKeyText(p.Q)
+	p.Amenity = normalizeKeyText(p.Amenity)
+	p.Street = normalizeKeyText(p.Street)
+	p.City = normalizeKeyText(p.City)
+	p.County = normalizeKeyText(p.County)
+	p.State = normalizeKeyText(p.State)
+	p.Country = normalizeKeyText(p.Country)
+	p.PostalCode = normalizeKeyText(p.PostalCode)
+	p.CountryCodes = strings.Join(normalizeKeyList(strings.Split(p.CountryCodes, ",")), ",")
+	p.AcceptLanguage = strings.ToLower(strings.ReplaceAll(p.AcceptLanguage, " ", ""))
+	p.FeatureType = strings.ToLower(strings.TrimSpace(p.FeatureType))
+	p.Layers = normalizeKeyList(p.Layers)
+	ids := append([]int64(nil), p.ExcludePlaceIDs...)
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	p.ExcludePlaceIDs = ids
+	return p
+}
+
+// cacheParams 返回用于缓存键的归一化逆地理参数；坐标取 5 位小数（约 1 米），提高相邻点的命中率。
+func (p ReverseParams) cacheParams() ReverseParams {
+	p.Lat = math.Round(p.Lat*1e5) / 1e5
+	p.Lon = math.Round(p.Lon*1e5) / 1e5
+	p.AcceptLanguage = strings.ToLower(strings.ReplaceAll(p.AcceptLanguage, " ", ""))
+	p.Layers = normalizeKeyList(p.Layers)
+	return p
+}
+
+// cacheParams 返回用于缓存键的归一化查找参数（结果按重要性排序，与 ID 顺序无关）。
+func (p LookupParams) cacheParams() LookupParams {
+	ids := make([]string, 0, len(p.OSMIDs))
+	for _, id := range p.OSMIDs {
+		ids = append(ids, strings.ToUpper(strings.TrimSpace(id)))
+	}
+	sort.Strings(ids)
+	p.OSMIDs = ids
+	p.AcceptLanguage = strings.ToLower(strings.ReplaceAll(p.AcceptLanguage, " ", ""))
+	return p
+}
@@ -0,0 +1,213 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+func TestCacheKey(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b any
+		same bool
+	}{
+		{
+			name: "search text case and whitespace",
+			a:    SearchParams{Q: "Berlin  Mitte", City: " Berlin"}.cacheParams(),
+			b:    SearchParams{Q: "berlin mitte", City: "BERLIN "}.cacheParams(),
+			same: true,
+		},
+		{
+			name: "search list order and duplicates",
+			a:    SearchParams{CountryCodes: "de,FR", Layers: []string{"poi", "address"}, ExcludePlaceIDs: []int64{3, 1}}.cacheParams(),
+			b:    SearchParams{CountryCodes: " fr,de,de", Layers: []string{"Address", "poi", ""}, ExcludePlaceIDs: []int64{1, 3}}.cacheParams(),
+			same: true,
+		},
+		{
+			name: "search accept-language spacing",
+			a:    SearchParams{Q: "x", AcceptLanguage: "zh, en"}.cacheParams(),
+			b:    SearchParams{Q: "x", AcceptLanguage: "ZH,EN"}.cacheParams(),
+			same: true,
+		},
+		{
+			name: "search different query",
+			a:    SearchParams{Q: "berlin"}.cacheParams(),
+			b:    SearchParams{Q: "bern"}.cacheParams(),
+		},
+		{
+			name: "search different limit",
+			a:    SearchParams{Q: "berlin", Limit: 10}.cacheParams(),
+			b:    SearchParams{Q: "berlin", Limit: 5}.cacheParams(),
+		},
+		{
+			name: "search language order matters",
+			a:    SearchParams{Q: "x", AcceptLanguage: "zh,en"}.cacheParams(),
+			b:    SearchParams{Q: "x", AcceptLanguage: "en,zh"}.cacheParams(),
+		},
+		{
+			name: "reverse coordinates within 5 decimals",
+			a:    ReverseParams{Lat: 52.5200001, Lon: 13.4049999, Zoom: 18}.cacheParams(),
+			b:    ReverseParams{Lat: 52.52, Lon: 13.405, Zoom: 18}.cacheParams(),
+			same: true,
+		},
+		{
+			name: "reverse different zoom",
+			a:    ReverseParams{Lat: 52.52, Lon: 13.405, Zoom: 18}.cacheParams(),
+			b:    ReverseParams{Lat: 52.52, Lon: 13.405, Zoom: 10}.cacheParams(),
+		},
+		{
+			name: "lookup id order and case",
+			a:    LookupParams{OSMIDs: []string{"r146656", "W104393803"}}.cacheParams(),
+			b:    LookupParams{OSMIDs: []string{" W104393803", "R146656"}}.cacheParams(),
+			same: true,
+		},
+		{
+			name: "lookup different details",
+			a:    LookupParams{OSMIDs: []string{"R146656"}, AddressDetails: true}.cacheParams(),
+			b:    LookupParams{OSMIDs: []string{"R146656"}}.cacheParams(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ka, kb := cacheKey(tt.a), cacheKey(tt.b)
+			if (ka == kb) != tt.same {
+				t.Fatalf("cacheKey equal = %v, want %v", ka == kb, tt.same)
+			}
+		})
+	}
+}
+
+// memCache 测试用内存缓存。
+type memCache struct {
+	mu sync.Mutex
+	m  map[string][]byte
+}
+
+func (c *memCache) Get(_ context.Context, endpoint, key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	b, ok := c.m[endpoint+":"+key]
+	return b, ok
+}
+
+func (c *memCache) Set(_ context.Context, endpoint, key string, val []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.m[endpoint+":"+key] = val
+}
+
+func TestCachedDetachedLoad(t *testing.T) {
+	cache := &memCache{m: map[string][]byte{}}
+	uc := NewSearchUsecase(nil, cache, log.DefaultLogger)
+	params := SearchParams{Q: "berlin"}
+
+	started, release := make(chan struct{}), make(chan struct{})
+	var loadErr error
+	load := func(ctx context.Context) ([]string, error) {
+		close(started)
+		<-release
+		loadErr = ctx.Err()
+		return []string{"berlin"}, nil
+	}
+
+	// 首个调用方在查询进行中取消
+	ctx1, cancel1 := context.WithCancel(context.Background())
+	err1 := make(chan error, 1)
+	go func() {
+		_, err := cached(ctx1, uc, CacheEndpointSearch, params, load)
+		err1 <- err
+	}()
+	<-started
+
+	// 第二个调用方合并到同一查询
+	res2 := make(chan []string, 1)
+	go func() {
+		v, err := cached(context.Background(), uc, CacheEndpointSearch, params, func(context.Context) ([]string, error) {
+			return nil, errors.New("load should be coalesced")
+		})
+		if err != nil {
+			t.Errorf("second caller: %v", err)
+		}
+		res2 <- v
+	}()
+
+	cancel1()
+	if err := <-err1; !errors.Is(err, context.Canceled) {
+		t.Fatalf("first caller err = %v, want context.Canceled", err)
+	}
+	// 等第二个调用方进入 singleflight 后再放行查询
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+
+	if v := <-res2; len(v) != 1 || v[0] != "berlin" {
+		t.Fatalf("second caller result = %v", v)
+	}
+	if loadErr != nil {
+		t.Fatalf("load context err = %v, want nil", loadErr)
+	}
+	if _, ok := cache.Get(context.Background(), CacheEndpointSearch, cacheKey(params)); !ok {
+		t.Fatal("result not written to cache")
+	}
+}
+
+// reverseRepo 测试用仓库：记录逆地理查询的坐标。
+type reverseRepo struct {
+	SearchRepo
+	got ReverseParams
+}
+
+func (r *reverseRepo) ReversePlace(_ context.Context, p ReverseParams) (*SearchPlace, error) {
+	r.got = p
+	return &SearchPlace{PlaceID: 1}, nil
+}
+
+func TestReverseRoundedLoad(t *testing.T) {
+	repo := &reverseRepo{}
+	uc := NewSearchUsecase(repo, &memCache{m: map[string][]byte{}}, log.DefaultLogger)
+	if _, err := uc.Reverse(context.Background(), ReverseParams{Lat: 52.5162749, Lon: 13.3777041}); err != nil {
+		t.Fatal(err)
+	}
+	if repo.got.Lat != 52.51627 || repo.got.Lon != 13.3777 {
+		t.Fatalf("queried (%v, %v), want the rounded cache key point (52.51627, 13.3777)", repo.got.Lat, repo.got.Lon)
+	}
+}
+
+func TestCachedCoalescesWithoutCache(t *testing.T) {
+	uc := NewSearchUsecase(nil, nil, log.DefaultLogger)
+	params := SearchParams{Q: "berlin"}
+
+	var calls atomic.Int32
+	started, release := make(chan struct{}), make(chan struct{})
+	load := func(context.Context) ([]string, error) {
+		if calls.Add(1) == 1 {
+			close(started)
+		}
+		<-release
+		return []string{"berlin"}, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if v, err := cached(context.Background(), uc, CacheEndpointSearch, params, load); err != nil || len(v) != 1 {
+				t.Errorf("cached = %v, %v", v, err)
+			}
+		}()
+	}
+	<-started
+	// 等其余调用方进入 singleflight 后再放行查询
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	if n := calls.Load(); n != 1 {
+		t.Fatalf("load calls = %d, want 1", n)
+	}
+}
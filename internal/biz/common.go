@@ -1,15 +1,21 @@
 package biz
 
 import (
+	"fmt"
+
 	"github.com/go-kratos/kratos/v2/errors"
 )
 
 var (
 	BadRequest     = "BAD_REQUEST"
 	Unauthorized   = "UNAUTHORIZED"
+	Forbidden      = "FORBIDDEN"
 	InternalServer = "INTERNAL_SERVER"
 	NotFound       = "NOT_FOUND"
 	Conflict       = "CONFLICT"
+	Timeout        = "TIMEOUT"
+	Unavailable    = "UNAVAILABLE"
+	Canceled       = "CANCELED"
 )
 
 const (
@@ -17,10 +23,25 @@ const (
 	OrderAsc  = "asc"
 )
 
+// 类型化错误：service 层将仓储/驱动错误映射为以下错误，HTTP 按 format 渲染（Nominatim 风格），
+// gRPC 状态码由 HTTP 状态码对应（400→InvalidArgument、404→NotFound、499→Canceled、503→Unavailable、504→DeadlineExceeded）。
 var (
 	ErrInternalServer = errors.New(500, "INTERNAL_SERVER", "internal server error")
+	// ErrNotFound 无匹配结果（对齐 Nominatim /reverse 的 "Unable to geocode"）
+	ErrNotFound = errors.NotFound(NotFound, "Unable to geocode")
+	// ErrTimeout 查询超时（请求截止时间或数据库 statement_timeout）
+	ErrTimeout = errors.GatewayTimeout(Timeout, "Query took too long to process.")
+	// ErrCanceled 调用方取消请求（客户端断开连接；499 沿用 nginx 的 Client Closed Request）
+	ErrCanceled = errors.New(499, Canceled, "Request canceled.")
+	// ErrUnavailable 数据库不可用（连接失败、连接中断、服务关闭中）
+	ErrUnavailable = errors.ServiceUnavailable(Unavailable, "Database unavailable.")
 )
 
+// ErrBadParameter 参数不合法（400），message 为 Nominatim 风格的说明。
+func ErrBadParameter(format string, args ...any) *errors.Error {
+	return errors.BadRequest(BadRequest, fmt.Sprintf(format, args...))
+}
+
 const (
 	HiddenSecret = "*****"
 )
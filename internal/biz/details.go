@@ -0,0 +1,85 @@
+package biz
+
+import (
+	"context"
+	"strings"
+
+	"nominatim-go/pkg/locale"
+)
+
+// PlaceDetails 对象详情（对齐 Nominatim 详情页，用于排查检索结果）。
+type PlaceDetails struct {
+	Place                *SearchPlace         // 基础信息（名称、等级、邮编、国家代码等）
+	ParentPlaceID        int64                // 父对象 place_id
+	LinkedPlaceID        int64                // 被关联到的对象 place_id
+	AdminLevel           int                  // 行政等级（无则 15）
+	IndexedDate          string               // 最近索引时间（ISO 8601）
+	AddressTags          map[string]string    // 地址标签（placex.address）
+	CalculatedPostcode   string               // 计算得到的邮编
+	CalculatedImportance float64              // 计算得到的重要性
+	Wikipedia            string               // 维基百科条目
+	IsArea               bool                 // 是否为面要素
+	Address              []DetailsAddressLine // 完整地址层级（含 isaddress=false 的行）
+	LinkedPlaces         []DetailsAddressLine // 关联到本对象的其他对象
+	NameKeywords         []Keyword            // search_name.name_vector 对应的词
+	AddressKeywords      []Keyword            // search_name.nameaddress_vector 对应的词
+}
+
+// DetailsAddressLine 详情中的地址层级/关联对象行。
+type DetailsAddressLine struct {
+	PlaceID     int64             // place_id
+	OSMType     string            // N/W/R
+	OSMID       int64             // OSM 数值 ID
+	Category    string            // 类别（class）
+	Type        string            // 类型（type）
+	AdminLevel  int               // 行政等级（无则 15）
+	RankAddress int               // 地址等级
+	Distance    float64           // 与对象的距离
+	IsAddress   bool              // 是否为地址组成部分
+	Names       map[string]string // 全部名称标签
+	ExtraTags   map[string]string // 额外标签（用于确定地址键）
+	LocalName   string            // 本地化名称（由 usecase 计算）
+	PlaceType   string            // 地址类型标签（由 usecase 计算）
+}
+
+// Keyword search_name 中的一个词。
+type Keyword struct {
+	ID    int64  // word_id
+	Token string // word_token
+}
+
+// DetailsParams 详情参数：place_id 优先，否则按 OSM 类型与 ID（可选 class）定位。
+type DetailsParams struct {
+	PlaceID        int64  // 内部 place_id
+	OSMType        string // N/W/R
+	OSMID          int64  // OSM 数值 ID
+	Class          string // 限定 class
+	AddressDetails bool   // 返回地址层级
+	Keywords       bool   // 返回关键词
+	LinkedPlaces   bool   // 返回关联对象
+	PolygonGeoJSON bool   // 返回几何 GeoJSON
+	AcceptLanguage string // 语言偏好
+}
+
+// Details 查询对象详情；未找到时返回 nil。
+func (uc *SearchUsecase) Details(ctx context.Context, p DetailsParams) (*PlaceDetails, error) {
+	p.OSMType = strings.ToUpper(strings.TrimSpace(p.OSMType))
+	validType := p.OSMType == "N" || p.OSMType == "W" || p.OSMType == "R"
+	if p.PlaceID <= 0 && (p.OSMID <= 0 || !validType) {
+		return nil, ErrBadParameter("Missing or invalid parameter: either place_id or osmtype/osmid is required.")
+	}
+	d, err := uc.repo.PlaceDetails(ctx, p)
+	if err != nil || d == nil {
+		return d, err
+	}
+	finishPlaces([]*SearchPlace{d.Place}, p.AcceptLanguage, false)
+	langs := locale.Parse(p.AcceptLanguage)
+	for _, lines := range [][]DetailsAddressLine{d.Address, d.LinkedPlaces} {
+		for i := range lines {
+			l := &lines[i]
+			l.LocalName = langs.Name(l.Names)
+			l.PlaceType = LabelTag(l.Category, l.Type, l.ExtraTags, l.RankAddress, d.Place.CountryCode)
+		}
+	}
+	return d, nil
+}
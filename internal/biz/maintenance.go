@@ -0,0 +1,71 @@
+package biz
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// DeletableObject 待删除对象：更新时被删除但尚未从 placex 移除的 OSM 对象。
+type DeletableObject struct {
+	PlaceID     int64  // 内部 place_id（placex 中已不存在时为 0）
+	CountryCode string // 国家代码
+	Name        string // 名称
+	OSMID       int64  // OSM 数值 ID
+	OSMType     string // N/W/R
+	Class       string // 类别
+	Type        string // 类型
+}
+
+// PolygonError 导入失败的多边形。
+type PolygonError struct {
+	PlaceID      int64    // 内部 place_id（对象未导入时为 0）
+	OSMType      string   // N/W/R
+	OSMID        int64    // OSM 数值 ID
+	Class        string   // 类别
+	Type         string   // 类型
+	Name         string   // 名称
+	CountryCode  string   // 国家代码
+	ErrorMessage string   // 错误信息
+	Updated      string   // 更新时间（ISO 8601）
+	ErrorLat     *float64 // 错误位置纬度（无法确定时为 nil）
+	ErrorLon     *float64 // 错误位置经度
+}
+
+// PolygonsParams 问题多边形过滤条件。
+type PolygonsParams struct {
+	Days    int    // 最近 N 天（0 表示不限）
+	Reduced bool   // 仅面积被缩减的记录
+	Class   string // 限定 class
+	Limit   int    // 返回条数上限
+}
+
+// MaintenanceRepo 维护端点的读路径（import_polygon_delete/import_polygon_error 等跟踪表）。
+type MaintenanceRepo interface {
+	ListDeletable(ctx context.Context) ([]*DeletableObject, error)
+	ListPolygonErrors(ctx context.Context, p PolygonsParams) ([]*PolygonError, error)
+}
+
+// MaintenanceUsecase 维护端点的业务逻辑。
+type MaintenanceUsecase struct {
+	repo MaintenanceRepo // 数据读取仓库
+	log  *log.Helper     // 日志
+}
+
+func NewMaintenanceUsecase(repo MaintenanceRepo, logger log.Logger) *MaintenanceUsecase {
+	return &MaintenanceUsecase{repo: repo, log: log.NewHelper(logger)}
+}
+
+// Deletable 列出待删除对象。
+func (uc *MaintenanceUsecase) Deletable(ctx context.Context) ([]*DeletableObject, error) {
+	return uc.repo.ListDeletable(ctx)
+}
+
+// Polygons 列出导入失败的多边形（按更新时间倒序，最多 1000 条）。
+func (uc *MaintenanceUsecase) Polygons(ctx context.Context, p PolygonsParams) ([]*PolygonError, error) {
+	if p.Days < 0 {
+		p.Days = 0
+	}
+	p.Limit = 1000
+	return uc.repo.ListPolygonErrors(ctx, p)
+}
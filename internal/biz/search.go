@@ -2,8 +2,12 @@ package biz
 
 import (
 	"context"
+	"strings"
+
+	"nominatim-go/pkg/locale"
 
 	"github.com/go-kratos/kratos/v2/log"
+	"golang.org/x/sync/singleflight"
 )
 
 // SearchPlace 为搜索/查找结果的传输结构。
@@ -14,6 +18,14 @@ type SearchPlace struct {
 	Category       string            // 类别（class）
 	Type           string            // 类型（type）
 	Name           string            // 展示名称（本地化前的基础名）
+	DisplayName    string            // 由地址层级拼接的本地化标签（由 usecase 计算）
+	LocalName      string            // 对象自身的本地化名称（由 usecase 计算）
+	AddressType    string            // 地址类型标签（由 usecase 计算）
+	RankAddress    int               // 地址等级（rank_address）
+	RankSearch     int               // 搜索等级（rank_search）
+	HouseNumber    string            // 门牌号
+	Postcode       string            // 邮编
+	CountryCode    string            // 国家代码（小写）
 	Lat            float64           // 纬度
 	Lon            float64           // 经度
 	Importance     float64           // 重要性分值
@@ -23,16 +35,25 @@ type SearchPlace struct {
 	BBoxEast       float64           // 边界框东
 	ExtraTags      map[string]string // 额外标签（当请求 extratags=true）
 	NameDetails    map[string]string // 名称细节（当请求 namedetails=true）
-	AddressRows    []AddressRowItem  // 地址行（当请求 addressdetails=true）
+	AddressRows    []AddressRowItem  // 地址行（由具体到宽泛，用于拼接展示名称；addressdetails=true 时输出）
+	Address        []AddressPart     // 地址对象（addressdetails=true 时由 usecase 生成）
 	PolygonGeoJSON string            // 多边形 GeoJSON（当请求 polygon_geojson=true）
 }
 
 // AddressRowItem 地址行元素。
 type AddressRowItem struct {
-	Component  string // 组件类型：country/state/city/road/house_number 等
-	Name       string // 名称
-	AdminLevel uint32 // 行政等级（无则 0）
-	Rank       uint32 // 排序等级
+	PlaceID     int64             // 地址对象 place_id
+	Category    string            // 类别（class）
+	Type        string            // 类型（type）
+	Component   string            // 组件类型：country/state/city/road/house_number 等
+	Name        string            // 名称（name 标签）
+	LocalName   string            // 本地化名称（由 usecase 按语言偏好计算）
+	Names       map[string]string // 全部名称标签（用于本地化）
+	CountryCode string            // 地址对象的国家代码（小写）
+	ExtraTags   map[string]string // 额外标签（用于确定地址键）
+	AdminLevel  uint32            // 行政等级（无则 0）
+	Rank        uint32            // 排序等级
+	IsAddress   bool              // 是否为地址组成部分（isaddress）
 }
 
 // SearchRepo 抽象读路径。
@@ -40,16 +61,19 @@ type SearchRepo interface {
 	SearchPlaces(ctx context.Context, p SearchParams) ([]*SearchPlace, error)
 	ReversePlace(ctx context.Context, p ReverseParams) (*SearchPlace, error)
 	LookupPlaces(ctx context.Context, p LookupParams) ([]*SearchPlace, error)
+	PlaceDetails(ctx context.Context, p DetailsParams) (*PlaceDetails, error)
 }
 
 // SearchUsecase 封装业务逻辑。
 type SearchUsecase struct {
-	repo SearchRepo  // 数据读取仓库
-	log  *log.Helper // 日志
+	repo  SearchRepo         // 数据读取仓库
+	cache SearchCache        // 结果缓存（未启用时为 nil）
+	group singleflight.Group // 合并相同的并发查询
+	log   *log.Helper        // 日志
 }
 
-func NewSearchUsecase(repo SearchRepo, logger log.Logger) *SearchUsecase {
-	return &SearchUsecase{repo: repo, log: log.NewHelper(logger)}
+func NewSearchUsecase(repo SearchRepo, cache SearchCache, logger log.Logger) *SearchUsecase {
+	return &SearchUsecase{repo: repo, cache: cache, log: log.NewHelper(logger)}
 }
 
 // SearchParams 搜索参数集合（与 proto 对齐，部分暂未使用）。
@@ -69,6 +93,14 @@ type SearchParams struct {
 	NameDetails      bool     // 返回 namedetails
 	ExcludePlaceIDs  []int64  // 排除的 place_id 列表
 	Layers           []string // layer 过滤：address, poi, railway, natural, manmade
+	// 结构化查询参数（与 Q 互斥，各组件分别约束其地址等级）
+	Amenity    string // POI 名称或类型
+	Street     string // 门牌号与街道
+	City       string // 城市
+	County     string // 县
+	State      string // 州/省
+	Country    string // 国家
+	PostalCode string // 邮政编码
 	// 视窗参数
 	ViewBoxLeft   float64 // 视窗左（最小经度）
 	ViewBoxTop    float64 // 视窗上（最大纬度）
@@ -101,14 +133,75 @@ type LookupParams struct {
 	NameDetails      bool     // 返回 namedetails
 }
 
+// IsStructured 是否为结构化查询。
+func (p SearchParams) IsStructured() bool {
+	return p.Amenity != "" || p.Street != "" || p.City != "" || p.County != "" ||
+		p.State != "" || p.Country != "" || p.PostalCode != ""
+}
+
 func (uc *SearchUsecase) Search(ctx context.Context, p SearchParams) ([]*SearchPlace, error) {
-	return uc.repo.SearchPlaces(ctx, p)
+	// 对齐 Nominatim：自由文本与结构化参数不可同时使用
+	if strings.TrimSpace(p.Q) != "" && p.IsStructured() {
+		return nil, ErrBadParameter("Structured query parameters (amenity, street, city, county, state, postalcode, country) cannot be used together with 'q' parameter.")
+	}
+	return cached(ctx, uc, CacheEndpointSearch, p.cacheParams(), func(ctx context.Context) ([]*SearchPlace, error) {
+		items, err := uc.repo.SearchPlaces(ctx, p)
+		if err != nil {
+			return nil, err
+		}
+		finishPlaces(items, p.AcceptLanguage, p.AddressDetails)
+		return items, nil
+	})
 }
 
 func (uc *SearchUsecase) Reverse(ctx context.Context, p ReverseParams) (*SearchPlace, error) {
-	return uc.repo.ReversePlace(ctx, p)
+	// 按缓存键取整后的坐标查询，同一缓存键下的结果与查询点一致
+	key := p.cacheParams()
+	p.Lat, p.Lon = key.Lat, key.Lon
+	return cached(ctx, uc, CacheEndpointReverse, key, func(ctx context.Context) (*SearchPlace, error) {
+		it, err := uc.repo.ReversePlace(ctx, p)
+		if err != nil || it == nil {
+			return it, err
+		}
+		finishPlaces([]*SearchPlace{it}, p.AcceptLanguage, p.AddressDetails)
+		return it, nil
+	})
 }
 
 func (uc *SearchUsecase) Lookup(ctx context.Context, p LookupParams) ([]*SearchPlace, error) {
-	return uc.repo.LookupPlaces(ctx, p)
+	return cached(ctx, uc, CacheEndpointLookup, p.cacheParams(), func(ctx context.Context) ([]*SearchPlace, error) {
+		items, err := uc.repo.LookupPlaces(ctx, p)
+		if err != nil {
+			return nil, err
+		}
+		finishPlaces(items, p.AcceptLanguage, p.AddressDetails)
+		return items, nil
+	})
+}
+
+// finishPlaces 确定地址行键，计算本地化名称、地址类型与展示名称；请求 addressdetails 时生成地址对象，否则不输出地址行。
+func finishPlaces(items []*SearchPlace, acceptLanguage string, addressDetails bool) {
+	langs := locale.Parse(acceptLanguage)
+	for _, it := range items {
+		for i := range it.AddressRows {
+			r := &it.AddressRows[i]
+			cc := r.CountryCode
+			if cc == "" {
+				cc = it.CountryCode
+			}
+			r.Component = LabelTag(r.Category, r.Type, r.ExtraTags, int(r.Rank), cc)
+			r.LocalName = langs.Name(r.Names)
+			if r.LocalName == "" {
+				r.LocalName = r.Name
+			}
+		}
+		it.LocalName = langs.Name(it.NameDetails)
+		it.AddressType = LabelTag(it.Category, it.Type, it.ExtraTags, it.RankAddress, it.CountryCode)
+		it.DisplayName = DisplayName(it, langs)
+		if addressDetails {
+			it.Address = AddressParts(it, langs)
+		} else {
+			it.AddressRows = nil
+		}
+	}
 }
@@ -0,0 +1,134 @@
+package conf
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// ApplyEnv 以环境变量覆盖配置（兼容旧的 NOMINATIM_* 变量）；加载与热更新后均需调用。
+func ApplyEnv(bc *Bootstrap) {
+	if bc.Nominatim == nil {
+		bc.Nominatim = &Nominatim{}
+	}
+	n := bc.Nominatim
+	if v := strings.TrimSpace(os.Getenv("NOMINATIM_LICENCE")); v != "" {
+		n.Licence = v
+	}
+	if v := strings.TrimSpace(os.Getenv("NOMINATIM_VERSION")); v != "" {
+		n.Version = v
+	}
+	if v := strings.TrimSpace(os.Getenv("NOMINATIM_DEFAULT_LANGUAGE")); v != "" {
+		n.DefaultLanguage = v
+	}
+	if v, ok := envBool("NOMINATIM_ENABLE_DETAILS"); ok {
+		n.EnableDetails = &v
+	}
+	if v, ok := envBool("NOMINATIM_ENABLE_MAINTENANCE"); ok {
+		n.EnableMaintenance = &v
+	}
+	if v, ok := envInt("NOMINATIM_MAX_RESULTS"); ok {
+		n.MaxLimit = int32(v)
+	}
+	if v := os.Getenv("NOMINATIM_RPS"); v != "" {
+		if rps, err := strconv.ParseFloat(v, 64); err == nil {
+			if bc.Server == nil {
+				bc.Server = &Server{}
+			}
+			if bc.Server.RateLimit == nil {
+				bc.Server.RateLimit = &Server_RateLimit{}
+			}
+			bc.Server.RateLimit.Rps = rps
+		}
+	}
+	if db := bc.GetData().GetDatabase(); db != nil {
+		if v, ok := envInt("NOMINATIM_DB_MAX_OPEN_CONNS"); ok {
+			db.MaxOpenConns = int32(v)
+		}
+		if v, ok := envInt("NOMINATIM_DB_MAX_IDLE_CONNS"); ok {
+			db.MaxIdleConns = int32(v)
+		}
+	}
+}
+
+func envBool(key string) (bool, bool) {
+	v := strings.TrimSpace(os.Getenv(key))
+	if v == "" {
+		return false, false
+	}
+	b, err := strconv.ParseBool(v)
+	return b, err == nil
+}
+
+func envInt(key string) (int, bool) {
+	v := strings.TrimSpace(os.Getenv(key))
+	if v == "" {
+		return 0, false
+	}
+	n, err := strconv.Atoi(v)
+	return n, err == nil
+}
+
+// Runtime 可热更新的配置：config.Watch 回调中整体替换，读取方每次取最新快照（不可修改返回值）。
+type Runtime struct {
+	nominatim atomic.Pointer[Nominatim]
+	rateLimit atomic.Pointer[Server_RateLimit]
+}
+
+// NewRuntime 以启动时的配置初始化。
+func NewRuntime(bc *Bootstrap) *Runtime {
+	r := &Runtime{}
+	r.Update(bc)
+	return r
+}
+
+// Update 替换可热更新的配置段（未变化的段保持原快照，便于读取方按指针判断是否变化）。
+func (r *Runtime) Update(bc *Bootstrap) {
+	n := bc.GetNominatim()
+	if n == nil {
+		n = &Nominatim{}
+	}
+	if old := r.nominatim.Load(); old == nil || !proto.Equal(old, n) {
+		r.nominatim.Store(proto.Clone(n).(*Nominatim))
+	}
+	rl := bc.GetServer().GetRateLimit()
+	if rl == nil {
+		rl = &Server_RateLimit{}
+	}
+	if old := r.rateLimit.Load(); old == nil || !proto.Equal(old, rl) {
+		r.rateLimit.Store(proto.Clone(rl).(*Server_RateLimit))
+	}
+}
+
+// Nominatim 当前的服务行为配置。
+func (r *Runtime) Nominatim() *Nominatim {
+	return r.nominatim.Load()
+}
+
+// RateLimit 当前的限流配置。
+func (r *Runtime) RateLimit() *Server_RateLimit {
+	return r.rateLimit.Load()
+}
+
+// Validate 校验限流配置：key 仅支持 ip/api_key（user_agent 由客户端控制，已不再支持），其他取值报错。
+func (rl *Server_RateLimit) Validate() error {
+	switch strings.ToLower(rl.GetKey()) {
+	case "", "ip", "api_key":
+		return nil
+	}
+	return fmt.Errorf("server.rate_limit.key: unsupported value %q (want ip or api_key)", rl.GetKey())
+}
+
+// DetailsEnabled 是否开放 /details（默认开放）。
+func (n *Nominatim) DetailsEnabled() bool {
+	return n.EnableDetails == nil || n.GetEnableDetails()
+}
+
+// MaintenanceEnabled 是否开放 /deletable、/polygons（默认开放）。
+func (n *Nominatim) MaintenanceEnabled() bool {
+	return n.EnableMaintenance == nil || n.GetEnableMaintenance()
+}
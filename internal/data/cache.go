@@ -0,0 +1,77 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"nominatim-go/internal/biz"
+	"nominatim-go/internal/conf"
+
+	"github.com/eko/gocache/lib/v4/store"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// NewSearchCache 基于 Data.Cache() 的查询结果缓存；未启用时返回 nil。
+func NewSearchCache(d *Data, c *conf.Data, logger log.Logger) biz.SearchCache {
+	cc := c.GetCache()
+	if !cc.GetEnabled() {
+		return nil
+	}
+	prefix := cc.GetPrefix()
+	if prefix == "" {
+		prefix = "nominatim:"
+	}
+	ttl := func(d time.Duration, def time.Duration) time.Duration {
+		if d > 0 {
+			return d
+		}
+		return def
+	}
+	return &searchCache{
+		data:   d,
+		prefix: prefix,
+		ttl: map[string]time.Duration{
+			biz.CacheEndpointSearch:  ttl(cc.GetSearchTtl().AsDuration(), 5*time.Minute),
+			biz.CacheEndpointReverse: ttl(cc.GetReverseTtl().AsDuration(), time.Hour),
+			biz.CacheEndpointLookup:  ttl(cc.GetLookupTtl().AsDuration(), time.Hour),
+		},
+		log: log.NewHelper(logger),
+	}
+}
+
+type searchCache struct {
+	data   *Data
+	prefix string
+	ttl    map[string]time.Duration
+	log    *log.Helper
+}
+
+func (c *searchCache) key(endpoint, key string) string {
+	return c.prefix + endpoint + ":" + key
+}
+
+func (c *searchCache) Get(ctx context.Context, endpoint, key string) ([]byte, bool) {
+	v, err := c.data.Cache().Get(ctx, c.key(endpoint, key))
+	if err != nil {
+		if !errors.Is(err, store.NotFound{}) {
+			c.log.WithContext(ctx).Warnf("cache get %s: %v", endpoint, err)
+		}
+		return nil, false
+	}
+	// 内存存储原样返回 []byte，Redis 返回 string
+	switch t := v.(type) {
+	case []byte:
+		return t, true
+	case string:
+		return []byte(t), true
+	default:
+		return nil, false
+	}
+}
+
+func (c *searchCache) Set(ctx context.Context, endpoint, key string, val []byte) {
+	if err := c.data.Cache().Set(ctx, c.key(endpoint, key), val, store.WithExpiration(c.ttl[endpoint])); err != nil {
+		c.log.WithContext(ctx).Warnf("cache set %s: %v", endpoint, err)
+	}
+}
@@ -3,7 +3,9 @@ package data
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"fmt"
+	"sync"
 	"time"
 
 	"nominatim-go/ent"
@@ -11,14 +13,18 @@ import (
 
 	entsql "entgo.io/ent/dialect/sql"
 	"github.com/eko/gocache/lib/v4/cache"
+	lib_store "github.com/eko/gocache/lib/v4/store"
 	"github.com/eko/gocache/store/go_cache/v4"
+	redis_store "github.com/eko/gocache/store/redis/v4"
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/stdlib"
 
 	"github.com/google/wire"
 	gocache "github.com/patrickmn/go-cache"
 	"github.com/qustavo/sqlhooks/v2"
+	"github.com/redis/go-redis/v9"
 
 	_ "github.com/go-sql-driver/mysql"
 	// sqlite "github.com/mattn/go-sqlite3"
@@ -31,6 +37,8 @@ var ProviderSet = wire.NewSet(
 	NewSqlDriver,
 	NewGreeterRepo,
 	NewSearchRepo,
+	NewSearchCache,
+	NewMaintenanceRepo,
 )
 
 // Data .
@@ -60,109 +68,219 @@ func (d *Data) Cache() cache.CacheInterface[any] {
 }
 
 // NewData .
+// drv 为 Nominatim 数据库（检索读路径）；ent 管理的应用表使用 data.app_database，未配置时与 drv 共用。
+// 服务启动不执行任何迁移，表结构经 nominatimctl migrate 创建。
 func NewData(
 	c *conf.Data,
 	drv *entsql.Driver,
 	logger log.Logger) (*Data, func(), error) {
-	goCache := gocache.New(5*time.Minute, 10*time.Minute)
-	store := go_cache.NewGoCache(goCache)
+	for _, db := range []*conf.Data_Database{c.GetDatabase(), c.GetAppDatabase()} {
+		if err := checkReadOnly(db); err != nil {
+			return nil, nil, err
+		}
+	}
+	var (
+		store       lib_store.StoreInterface
+		redisClient *redis.Client
+	)
+	// 缓存存储：redis 使用 data.redis，其余使用进程内存
+	if c.GetCache().GetStore() == "redis" {
+		redisClient = redis.NewClient(&redis.Options{
+			Network:      c.GetRedis().GetNetwork(),
+			Addr:         c.GetRedis().GetAddr(),
+			ReadTimeout:  c.GetRedis().GetReadTimeout().AsDuration(),
+			WriteTimeout: c.GetRedis().GetWriteTimeout().AsDuration(),
+		})
+		store = redis_store.NewRedis(redisClient)
+	} else {
+		store = go_cache.NewGoCache(gocache.New(5*time.Minute, 10*time.Minute))
+	}
 	cacheManager := cache.New[any](store)
+	appDrv := drv
+	if hasAppDatabase(c) {
+		appDrv = openDriver(c.AppDatabase)
+	}
 	data := &Data{
-		entClient: NewEnt(drv),
+		entClient: NewEnt(appDrv),
 		conf:      c,
 		cache:     cacheManager,
 		sqlDrv:    drv,
 	}
 	// 设置 debug 模式
-	if c.Database.Debug {
+	if appDatabase(c).GetDebug() {
 		data.entClient = data.entClient.Debug()
 	}
 	cleanup := func() {
 		log.NewHelper(logger).Info("closing the data resources")
 		data.entClient.Close()
+		if appDrv != drv {
+			_ = drv.Close()
+		}
+		if redisClient != nil {
+			_ = redisClient.Close()
+		}
 	}
-	// Run the auto migration tool.
-	if err := data.entClient.Schema.Create(context.Background()); err != nil {
-		return nil, nil, err
-	}
-	// 已自动迁移，无需额外操作
 	return data, cleanup, nil
 }
 
+// hasAppDatabase 是否单独配置了应用表数据库。
+func hasAppDatabase(c *conf.Data) bool {
+	return c.GetAppDatabase().GetSource() != ""
+}
+
+// appDatabase 返回应用表（ent）所用的数据库配置。
+func appDatabase(c *conf.Data) *conf.Data_Database {
+	if hasAppDatabase(c) {
+		return c.AppDatabase
+	}
+	return c.Database
+}
+
+// checkReadOnly 只读仅能由 PostgreSQL 会话强制（default_transaction_read_only），其他驱动拒绝启动。
+func checkReadOnly(db *conf.Data_Database) error {
+	if db.GetReadOnly() && !isPostgresDriver(db.GetDriver()) {
+		return fmt.Errorf("read_only is only supported with the postgres driver, got %q", db.GetDriver())
+	}
+	return nil
+}
+
+// isPostgresDriver 是否为 PostgreSQL 驱动名。
+func isPostgresDriver(name string) bool {
+	switch name {
+	case "postgres", "postgresql", "pgx":
+		return true
+	}
+	return false
+}
+
+// Migrate 创建/更新 ent 管理的应用表（供 nominatimctl migrate 调用）；只读数据库拒绝迁移。
+func Migrate(ctx context.Context, c *conf.Data) error {
+	db := appDatabase(c)
+	if db.GetReadOnly() {
+		return fmt.Errorf("database %q is read-only; configure data.app_database for the application tables", db.GetDriver())
+	}
+	if db.GetDriver() == "mysql" {
+		if err := createMySQLDatabase(db); err != nil {
+			return err
+		}
+	}
+	drv := openDriver(db)
+	client := NewEnt(drv)
+	defer client.Close()
+	return client.Schema.Create(ctx)
+}
+
+// NewSqlDriver 打开 Nominatim 数据库（data.database）。
 func NewSqlDriver(conf *conf.Data) *entsql.Driver {
-	switch conf.Database.Driver {
+	return openDriver(conf.Database)
+}
+
+func openDriver(c *conf.Data_Database) *entsql.Driver {
+	switch c.GetDriver() {
 	case "mysql":
-		return newMySqlDriver(conf)
+		return newMySqlDriver(c)
 	case "sqlite3":
-		return newSqliteDriver(conf)
+		return newSqliteDriver(c)
 	case "postgres", "postgresql", "pgx":
-		return newPostgresDriver(conf)
+		return newPostgresDriver(c)
 	default:
-		panic(fmt.Sprintf("unsupported driver: %s", conf.Database.Driver))
+		panic(fmt.Sprintf("unsupported driver: %s", c.GetDriver()))
+	}
+}
+
+var registered sync.Map
+
+// registerDriver 注册带 SQL 钩子的驱动（同名只注册一次，两个数据库可共用）。
+func registerDriver(name string, d driver.Driver) {
+	if _, loaded := registered.LoadOrStore(name, true); !loaded {
+		sql.Register(name, sqlhooks.Wrap(d, &Hooks{}))
 	}
 }
 
-func newSqliteDriver(conf *conf.Data) *entsql.Driver {
-	sql.Register("sqlite3WithHooks", sqlhooks.Wrap(&sqlite.Driver{}, &Hooks{}))
-	db, err := sql.Open("sqlite3WithHooks", conf.Database.Source)
+func newSqliteDriver(c *conf.Data_Database) *entsql.Driver {
+	registerDriver("sqlite3WithHooks", &sqlite.Driver{})
+	db, err := sql.Open("sqlite3WithHooks", c.Source)
 	if err != nil {
 		panic(err)
 	}
-	db.SetMaxIdleConns(10)
-	db.SetMaxOpenConns(100)
-	db.SetConnMaxLifetime(time.Hour)
-	db.SetConnMaxIdleTime(time.Minute * 10)
+	configurePool(db, c)
 	drv := entsql.OpenDB("sqlite3", db)
 	return drv
 }
 
-func newMySqlDriver(conf *conf.Data) *entsql.Driver {
-	sql.Register("mysqlWithHooks", sqlhooks.Wrap(&mysql.MySQLDriver{}, &Hooks{}))
-	cfg, err := mysql.ParseDSN(conf.Database.Source)
+// createMySQLDatabase 自动创建数据库（仅迁移时执行）。
+func createMySQLDatabase(c *conf.Data_Database) error {
+	registerDriver("mysqlWithHooks", &mysql.MySQLDriver{})
+	cfg, err := mysql.ParseDSN(c.Source)
 	if err != nil {
-		panic(err)
+		return err
 	}
 	DBName := cfg.DBName
 	// 去除数据库名字
 	cfg.DBName = ""
-	tsource := cfg.FormatDSN()
-	tdb, err := sql.Open("mysqlWithHooks", tsource)
+	tdb, err := sql.Open("mysqlWithHooks", cfg.FormatDSN())
 	if err != nil {
-		panic(err)
+		return err
 	}
 	defer tdb.Close()
-	// 自动创建数据库
 	_, err = tdb.Exec(fmt.Sprintf("CREATE DATABASE IF NOT EXISTS %s CHARACTER SET utf8mb4", DBName))
-	if err != nil {
-		panic(err)
-	}
+	return err
+}
 
-	db, err := sql.Open("mysqlWithHooks", conf.Database.Source)
+func newMySqlDriver(c *conf.Data_Database) *entsql.Driver {
+	registerDriver("mysqlWithHooks", &mysql.MySQLDriver{})
+	db, err := sql.Open("mysqlWithHooks", c.Source)
 	if err != nil {
 		panic(err)
 	}
-	db.SetMaxIdleConns(10)
-	db.SetMaxOpenConns(100)
-	db.SetConnMaxLifetime(time.Hour)
-	db.SetConnMaxIdleTime(time.Minute * 10)
+	configurePool(db, c)
 	drv := entsql.OpenDB("mysql", db)
 	return drv
 }
 
-func newPostgresDriver(conf *conf.Data) *entsql.Driver {
-	sql.Register("pgxWithHooks", sqlhooks.Wrap(&stdlib.Driver{}, &Hooks{}))
-	db, err := sql.Open("pgxWithHooks", conf.Database.Source)
+func newPostgresDriver(c *conf.Data_Database) *entsql.Driver {
+	registerDriver("pgxWithHooks", stdlib.GetDefaultDriver())
+	source := c.Source
+	// 只读：会话级禁止写入（含 DDL）
+	if c.GetReadOnly() {
+		cfg, err := pgx.ParseConfig(source)
+		if err != nil {
+			panic(err)
+		}
+		cfg.RuntimeParams["default_transaction_read_only"] = "on"
+		source = stdlib.RegisterConnConfig(cfg)
+	}
+	db, err := sql.Open("pgxWithHooks", source)
 	if err != nil {
 		panic(err)
 	}
-	db.SetMaxIdleConns(10)
-	db.SetMaxOpenConns(100)
-	db.SetConnMaxLifetime(time.Hour)
-	db.SetConnMaxIdleTime(time.Minute * 10)
+	configurePool(db, c)
 	drv := entsql.OpenDB("pgx", db)
 	return drv
 }
 
+// configurePool 按 data.database 设置连接池（未配置时使用默认值）。
+func configurePool(db *sql.DB, c *conf.Data_Database) {
+	maxIdle, maxOpen := int(c.GetMaxIdleConns()), int(c.GetMaxOpenConns())
+	if maxIdle <= 0 {
+		maxIdle = 10
+	}
+	if maxOpen <= 0 {
+		maxOpen = 100
+	}
+	lifetime, idleTime := time.Hour, time.Minute*10
+	if c.GetConnMaxLifetime() != nil {
+		lifetime = c.GetConnMaxLifetime().AsDuration()
+	}
+	if c.GetConnMaxIdleTime() != nil {
+		idleTime = c.GetConnMaxIdleTime().AsDuration()
+	}
+	db.SetMaxIdleConns(maxIdle)
+	db.SetMaxOpenConns(maxOpen)
+	db.SetConnMaxLifetime(lifetime)
+	db.SetConnMaxIdleTime(idleTime)
+}
+
 func NewEnt(drv *entsql.Driver) *ent.Client {
 	client := ent.NewClient(ent.Driver(drv))
 	return client
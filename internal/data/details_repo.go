@@ -0,0 +1,185 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"nominatim-go/internal/biz"
+)
+
+// detailsColumns 详情附加列（顺序须与 PlaceDetails 扫描一致）。
+const detailsColumns = `
+       COALESCE(p.parent_place_id, 0) AS parent_place_id,
+       COALESCE(p.linked_place_id, 0) AS linked_place_id,
+       COALESCE(p.admin_level, 15) AS admin_level,
+       COALESCE(to_char(p.indexed_date, 'YYYY-MM-DD"T"HH24:MI:SS"+00:00"'), '') AS indexed_date,
+       COALESCE(hstore_to_json(p.address)::text, '{}') AS address_json,
+       COALESCE(p.postcode, (
+         SELECT lp.postcode FROM location_postcode lp
+         WHERE lp.country_code = p.country_code
+         ORDER BY lp.geometry <-> p.centroid LIMIT 1
+       ), '') AS calculated_postcode,
+       COALESCE(p.importance, 0.40001 - p.rank_search::float / 75) AS calculated_importance,
+       COALESCE(p.wikipedia, '') AS wikipedia,
+       ST_GeometryType(p.geometry) IN ('ST_Polygon', 'ST_MultiPolygon') AS isarea`
+
+// PlaceDetails 读取对象详情：place_id 优先，否则按 osm_type/osm_id（可选 class）定位。
+func (r *searchRepo) PlaceDetails(ctx context.Context, p biz.DetailsParams) (*biz.PlaceDetails, error) {
+	if !r.isPostgres() {
+		return nil, nil
+	}
+	db := r.sqlDB()
+	if db == nil {
+		return nil, nil
+	}
+
+	var (
+		where string
+		args  []any
+	)
+	if p.PlaceID > 0 {
+		where = "p.place_id = $1"
+		args = append(args, p.PlaceID)
+	} else {
+		where = "p.osm_type = $1 AND p.osm_id = $2"
+		args = append(args, p.OSMType, p.OSMID)
+		if p.Class != "" {
+			where += " AND p.class = $3"
+			args = append(args, p.Class)
+		}
+	}
+	q := `
+SELECT ` + placeColumns("p", geoJSONColumn("p", p.PolygonGeoJSON, 0)) + `,` + detailsColumns + `
+FROM placex p
+WHERE ` + where + `
+ORDER BY p.class
+LIMIT 1`
+
+	d := &biz.PlaceDetails{}
+	var addressJSON string
+	place, err := scanPlace(extraScanner{
+		sc: db.QueryRowContext(ctx, q, args...),
+		extra: []any{&d.ParentPlaceID, &d.LinkedPlaceID, &d.AdminLevel, &d.IndexedDate, &addressJSON,
+			&d.CalculatedPostcode, &d.CalculatedImportance, &d.Wikipedia, &d.IsArea},
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	_ = json.Unmarshal([]byte(addressJSON), &d.AddressTags)
+	d.Place = place
+
+	// 展示名称所需的地址行
+	if err := r.attachAddressRows(ctx, db, []*biz.SearchPlace{place}); err != nil {
+		return nil, err
+	}
+	if p.AddressDetails {
+		if d.Address, err = r.detailsAddressLines(ctx, db, place.PlaceID); err != nil {
+			return nil, err
+		}
+	}
+	if p.LinkedPlaces {
+		if d.LinkedPlaces, err = r.detailsLinkedPlaces(ctx, db, place.PlaceID); err != nil {
+			return nil, err
+		}
+	}
+	if p.Keywords {
+		if d.NameKeywords, d.AddressKeywords, err = r.detailsKeywords(ctx, db, place.PlaceID); err != nil {
+			return nil, err
+		}
+	}
+	return d, nil
+}
+
+// detailsLineColumns 地址层级/关联对象行的列（顺序须与 scanDetailsLines 一致）。
+const detailsLineColumns = `a.place_id, a.osm_type, a.osm_id, a.class, a.type,
+       COALESCE(a.admin_level, 15) AS admin_level,
+       COALESCE(hstore_to_json(a.name)::text, '{}') AS name_json,
+       COALESCE(hstore_to_json(a.extratags)::text, '{}') AS extratags_json`
+
+// detailsAddressLines 完整地址层级：与 fetchAddressRows 同源，但保留 isaddress=false 与等级为 0 的行。
+func (r *searchRepo) detailsAddressLines(ctx context.Context, db *sql.DB, placeID int64) ([]biz.DetailsAddressLine, error) {
+	q := addressLinesCTE + `
+SELECT ` + detailsLineColumns + `,
+       COALESCE(l.cached_rank_address, 0) AS rank_address,
+       COALESCE(l.distance, 0) AS distance,
+       COALESCE(l.isaddress, false) AS isaddress
+FROM lines l
+JOIN placex a ON a.place_id = l.address_place_id
+ORDER BY l.cached_rank_address DESC, l.isaddress DESC, l.distance ASC`
+	return scanDetailsLines(db.QueryContext(ctx, q, placeIDArray([]int64{placeID})))
+}
+
+// detailsLinkedPlaces 关联到本对象的其他对象（placex.linked_place_id）。
+func (r *searchRepo) detailsLinkedPlaces(ctx context.Context, db *sql.DB, placeID int64) ([]biz.DetailsAddressLine, error) {
+	q := `
+SELECT ` + detailsLineColumns + `,
+       COALESCE(a.rank_address, 0) AS rank_address,
+       COALESCE(ST_Distance(p.centroid, a.centroid), 0) AS distance,
+       false AS isaddress
+FROM placex a
+JOIN placex p ON p.place_id = a.linked_place_id
+WHERE a.linked_place_id = $1
+ORDER BY a.rank_address DESC, a.place_id`
+	return scanDetailsLines(db.QueryContext(ctx, q, placeID))
+}
+
+func scanDetailsLines(rows *sql.Rows, err error) ([]biz.DetailsAddressLine, error) {
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []biz.DetailsAddressLine
+	for rows.Next() {
+		var (
+			l                       biz.DetailsAddressLine
+			nameJSON, extratagsJSON string
+		)
+		if err := rows.Scan(&l.PlaceID, &l.OSMType, &l.OSMID, &l.Category, &l.Type, &l.AdminLevel, &nameJSON, &extratagsJSON,
+			&l.RankAddress, &l.Distance, &l.IsAddress); err != nil {
+			return nil, err
+		}
+		_ = json.Unmarshal([]byte(nameJSON), &l.Names)
+		_ = json.Unmarshal([]byte(extratagsJSON), &l.ExtraTags)
+		out = append(out, l)
+	}
+	return out, rows.Err()
+}
+
+// detailsKeywords 读取 search_name 中名称向量与地址向量对应的词。
+func (r *searchRepo) detailsKeywords(ctx context.Context, db *sql.DB, placeID int64) (name, address []biz.Keyword, err error) {
+	q := `
+SELECT 'name', w.word_id, w.word_token
+FROM search_name s CROSS JOIN LATERAL unnest(s.name_vector) AS v(id)
+JOIN word w ON w.word_id = v.id
+WHERE s.place_id = $1
+UNION ALL
+SELECT 'address', w.word_id, w.word_token
+FROM search_name s CROSS JOIN LATERAL unnest(s.nameaddress_vector) AS v(id)
+JOIN word w ON w.word_id = v.id
+WHERE s.place_id = $1`
+	rows, err := db.QueryContext(ctx, q, placeID)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			kind string
+			kw   biz.Keyword
+		)
+		if err := rows.Scan(&kind, &kw.ID, &kw.Token); err != nil {
+			return nil, nil, err
+		}
+		if kind == "name" {
+			name = append(name, kw)
+		} else {
+			address = append(address, kw)
+		}
+	}
+	return name, address, rows.Err()
+}
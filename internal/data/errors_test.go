@@ -0,0 +1,43 @@
+package data
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func TestErrorClass(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		canceled    bool
+		timeout     bool
+		unavailable bool
+	}{
+		{name: "canceled", err: fmt.Errorf("query: %w", context.Canceled), canceled: true},
+		{name: "deadline", err: fmt.Errorf("query: %w", context.DeadlineExceeded), timeout: true},
+		{name: "statement timeout", err: &pgconn.PgError{Code: "57014"}, timeout: true},
+		{name: "admin shutdown", err: &pgconn.PgError{Code: "57P01"}, unavailable: true},
+		{name: "connection exception", err: &pgconn.PgError{Code: "08006"}, unavailable: true},
+		{name: "bad conn", err: driver.ErrBadConn, unavailable: true},
+		{name: "syntax error", err: &pgconn.PgError{Code: "42601"}},
+		{name: "other", err: errors.New("boom")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsCanceled(tt.err); got != tt.canceled {
+				t.Errorf("IsCanceled = %v, want %v", got, tt.canceled)
+			}
+			if got := IsTimeout(tt.err); got != tt.timeout {
+				t.Errorf("IsTimeout = %v, want %v", got, tt.timeout)
+			}
+			if got := IsUnavailable(tt.err); got != tt.unavailable {
+				t.Errorf("IsUnavailable = %v, want %v", got, tt.unavailable)
+			}
+		})
+	}
+}
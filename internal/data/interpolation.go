@@ -0,0 +1,126 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+
+	"nominatim-go/internal/biz"
+)
+
+// 以下实现对齐 Nominatim 的门牌插值（location_property_osmline）：
+//   - 插值线 linegeo 覆盖 startnumber..endnumber（步长 step），parent_place_id 为所属街道；
+//   - 门牌位置按号码在区间中的比例沿线插值（首尾相同时取线的中点），结果为 osm_type=W 的 place/house 点。
+
+// interpolationColumns 插值结果的通用列（顺序须与 scanPlace 一致）。
+// alias 须提供 place_id、osm_id、postcode、address、country_code、hnr（门牌号）与 geometry（插值点）。
+func interpolationColumns(alias, geoJSON string) string {
+	a := alias + "."
+	return a + `place_id, ` + a + `osm_id, 'W' AS osm_type, 'place' AS class, 'house' AS type,
+       '' AS name,
+       COALESCE(ST_Y(` + a + `geometry), 0) AS lat,
+       COALESCE(ST_X(` + a + `geometry), 0) AS lon,
+       0.00001 AS importance,
+       COALESCE(ST_Y(` + a + `geometry), 0) AS south,
+       COALESCE(ST_Y(` + a + `geometry), 0) AS north,
+       COALESCE(ST_X(` + a + `geometry), 0) AS west,
+       COALESCE(ST_X(` + a + `geometry), 0) AS east,
+       '{}' AS name_json,
+       '{}' AS extratags_json,
+       ` + geoJSON + ` AS polygon_geojson,
+       30 AS rank_address,
+       30 AS rank_search,
+       ` + a + `hnr::text AS housenumber,
+       COALESCE(` + a + `postcode, ` + a + `address->'postcode', '') AS postcode,
+       COALESCE(` + a + `country_code, '') AS country_code`
+}
+
+// hasHousenumber 结果中是否已有该门牌的实际对象（门牌可为 ; 分隔的多个号码）。
+func hasHousenumber(items []*biz.SearchPlace, housenumber string) bool {
+	for _, it := range items {
+		if it.RankAddress != 30 || it.HouseNumber == "" {
+			continue
+		}
+		for _, h := range strings.Split(strings.ToLower(it.HouseNumber), ";") {
+			if strings.TrimSpace(h) == housenumber {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// interpolationNumber 解析用于插值的门牌号：仅纯数字，且须在 int4 范围内（与 osmline 的 startnumber/endnumber 一致），否则不插值。
+func interpolationNumber(housenumber string) (int, bool) {
+	n, err := strconv.ParseInt(housenumber, 10, 32)
+	if err != nil || n < 0 {
+		return 0, false
+	}
+	return int(n), true
+}
+
+// interpolateHousenumbers 门牌未命中实际对象时，将结果中的街道替换为其插值线上的估算门牌点；仅支持纯数字门牌。
+func (r *searchRepo) interpolateHousenumbers(ctx context.Context, db *sql.DB, p biz.SearchParams, items []*biz.SearchPlace, housenumber string) ([]*biz.SearchPlace, error) {
+	hnr, ok := interpolationNumber(housenumber)
+	if !ok || hasHousenumber(items, housenumber) {
+		return items, nil
+	}
+	var streets []int64
+	for _, it := range items {
+		if it.RankAddress == 26 || it.RankAddress == 27 {
+			streets = append(streets, it.PlaceID)
+		}
+	}
+	if len(streets) == 0 {
+		return items, nil
+	}
+	q := `
+SELECT ` + interpolationColumns("i", geoJSONColumn("i", p.PolygonGeoJSON, p.PolygonThreshold)) + `, i.parent_place_id
+FROM (
+  SELECT DISTINCT ON (o.parent_place_id)
+         o.place_id, o.osm_id, o.parent_place_id, o.postcode, o.address, o.country_code, $1::int AS hnr,
+         CASE WHEN o.endnumber = o.startnumber THEN ST_LineInterpolatePoint(o.linegeo, 0.5)
+              ELSE ST_LineInterpolatePoint(o.linegeo, ($1 - o.startnumber)::float / (o.endnumber - o.startnumber))
+         END AS geometry
+  FROM location_property_osmline o
+  WHERE o.parent_place_id = ANY($2::bigint[])
+    AND o.startnumber IS NOT NULL
+    AND o.indexed_status = 0
+    AND $1 BETWEEN o.startnumber AND o.endnumber
+    AND o.step > 0
+    AND ($1 - o.startnumber) % o.step = 0
+  ORDER BY o.parent_place_id, o.place_id
+) i`
+	rows, err := db.QueryContext(ctx, q, hnr, placeIDArray(streets))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	byStreet := map[int64]*biz.SearchPlace{}
+	var found []*biz.SearchPlace
+	for rows.Next() {
+		var parent int64
+		it, err := scanPlace(extraScanner{sc: rows, extra: []any{&parent}})
+		if err != nil {
+			return nil, err
+		}
+		byStreet[parent] = it
+		found = append(found, it)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(found) == 0 {
+		return items, nil
+	}
+	if err := r.attachAddressRows(ctx, db, found); err != nil {
+		return nil, err
+	}
+	for i, it := range items {
+		if house, ok := byStreet[it.PlaceID]; ok {
+			items[i] = house
+		}
+	}
+	return items, nil
+}
@@ -0,0 +1,28 @@
+package data
+
+import "testing"
+
+func TestInterpolationNumber(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int
+		ok   bool
+	}{
+		{in: "12", want: 12, ok: true},
+		{in: "0007", want: 7, ok: true},
+		{in: "2147483647", want: 2147483647, ok: true},
+		{in: "2147483648"},
+		{in: "99999999999999999999"},
+		{in: "12a"},
+		{in: "-3"},
+		{in: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, ok := interpolationNumber(tt.in)
+			if got != tt.want || ok != tt.ok {
+				t.Fatalf("interpolationNumber(%q) = %d, %v; want %d, %v", tt.in, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
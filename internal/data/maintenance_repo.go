@@ -0,0 +1,114 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+
+	"nominatim-go/internal/biz"
+)
+
+// NewMaintenanceRepo 维护端点仓库。
+func NewMaintenanceRepo(d *Data) biz.MaintenanceRepo {
+	return &maintenanceRepo{searchRepo{data: d}}
+}
+
+// maintenanceRepo 复用 searchRepo 的数据库判定。
+type maintenanceRepo struct {
+	searchRepo
+}
+
+// ListDeletable 对齐 Nominatim /deletable：import_polygon_delete 记录更新时被删除的对象，
+// 关联 placex 取 place_id、名称与国家代码（对象可能已被移除）。
+func (r *maintenanceRepo) ListDeletable(ctx context.Context) ([]*biz.DeletableObject, error) {
+	if !r.isPostgres() {
+		return []*biz.DeletableObject{}, nil
+	}
+	db := r.sqlDB()
+	if db == nil {
+		return []*biz.DeletableObject{}, nil
+	}
+	q := `
+SELECT COALESCE(p.place_id, 0), COALESCE(p.country_code, ''), COALESCE(p.name->'name', ''),
+       i.osm_id, i.osm_type, i.class, i.type
+FROM import_polygon_delete i
+LEFT JOIN placex p ON p.osm_type = i.osm_type AND p.osm_id = i.osm_id
+                  AND p.class = i.class AND p.type = i.type
+ORDER BY i.osm_type, i.osm_id`
+	rows, err := db.QueryContext(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	out := []*biz.DeletableObject{}
+	for rows.Next() {
+		var o biz.DeletableObject
+		if err := rows.Scan(&o.PlaceID, &o.CountryCode, &o.Name, &o.OSMID, &o.OSMType, &o.Class, &o.Type); err != nil {
+			return nil, err
+		}
+		out = append(out, &o)
+	}
+	return out, rows.Err()
+}
+
+// ListPolygonErrors 对齐 Nominatim /polygons：读取 import_polygon_error，可按天数、面积缩减与 class 过滤；
+// 错误位置取自 ST_IsValidDetail(newgeometry)。
+func (r *maintenanceRepo) ListPolygonErrors(ctx context.Context, p biz.PolygonsParams) ([]*biz.PolygonError, error) {
+	if !r.isPostgres() {
+		return []*biz.PolygonError{}, nil
+	}
+	db := r.sqlDB()
+	if db == nil {
+		return []*biz.PolygonError{}, nil
+	}
+	where := []string{"true"}
+	args := []any{}
+	if p.Days > 0 {
+		args = append(args, p.Days)
+		where = append(where, "e.updated > now() - make_interval(days => $"+strconv.Itoa(len(args))+")")
+	}
+	if p.Reduced {
+		where = append(where, "e.errormessage LIKE 'Area reduced%'")
+	}
+	if p.Class != "" {
+		args = append(args, p.Class)
+		where = append(where, "e.class = $"+strconv.Itoa(len(args)))
+	}
+	args = append(args, p.Limit)
+	q := `
+SELECT COALESCE(pl.place_id, 0), e.osm_type, e.osm_id, e.class, e.type,
+       COALESCE(e.name->'name', ''), COALESCE(e.country_code, ''), COALESCE(e.errormessage, ''),
+       COALESCE(to_char(e.updated, 'YYYY-MM-DD"T"HH24:MI:SS"+00:00"'), ''),
+       ST_Y(d.location), ST_X(d.location)
+FROM import_polygon_error e
+LEFT JOIN LATERAL (
+  SELECT (ST_IsValidDetail(e.newgeometry)).location
+  WHERE e.newgeometry IS NOT NULL
+) d ON true
+LEFT JOIN placex pl ON pl.osm_type = e.osm_type AND pl.osm_id = e.osm_id
+                   AND pl.class = e.class AND pl.type = e.type
+WHERE ` + strings.Join(where, " AND ") + `
+ORDER BY e.updated DESC
+LIMIT $` + strconv.Itoa(len(args))
+	rows, err := db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	out := []*biz.PolygonError{}
+	for rows.Next() {
+		var (
+			e        biz.PolygonError
+			lat, lon sql.NullFloat64
+		)
+		if err := rows.Scan(&e.PlaceID, &e.OSMType, &e.OSMID, &e.Class, &e.Type, &e.Name, &e.CountryCode, &e.ErrorMessage, &e.Updated, &lat, &lon); err != nil {
+			return nil, err
+		}
+		if lat.Valid && lon.Valid {
+			e.ErrorLat, e.ErrorLon = &lat.Float64, &lon.Float64
+		}
+		out = append(out, &e)
+	}
+	return out, rows.Err()
+}
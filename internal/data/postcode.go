@@ -0,0 +1,280 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"sort"
+	"strings"
+
+	"nominatim-go/internal/biz"
+)
+
+// 以下实现对齐 Nominatim 的邮编处理：
+//   - 邮编按国家格式识别（country_settings 的 postcode.pattern/output），规范写法即 location_postcode.postcode 的存储形式；
+//   - location_postcode(place_id, parent_place_id, rank_search, rank_address, country_code, postcode, geometry)：
+//     邮编只有中心点，结果的边界按邮编精度（rank_search）由中心点外扩得到。
+
+// postcodeFormat 国家邮编格式：pattern 中 d 为数字、l 为字母；output 为规范写法。
+type postcodeFormat struct {
+	re     *regexp.Regexp
+	output string
+}
+
+func newPostcodeFormat(pattern, output string) postcodeFormat {
+	var b strings.Builder
+	for _, r := range pattern {
+		switch r {
+		case 'd':
+			b.WriteString("[0-9]")
+		case 'l':
+			b.WriteString("[A-Z]")
+		default:
+			b.WriteRune(r)
+		}
+	}
+	if output == "" {
+		output = "${0}"
+	}
+	return postcodeFormat{re: regexp.MustCompile("^(?:" + b.String() + ")$"), output: output}
+}
+
+// postcodeFormats 常见国家的邮编格式（国家代码小写）；未列出的国家不在自由文本中识别邮编。
+var postcodeFormats = map[string]postcodeFormat{
+	"at": newPostcodeFormat("dddd", ""),
+	"au": newPostcodeFormat("dddd", ""),
+	"be": newPostcodeFormat("dddd", ""),
+	"br": newPostcodeFormat("(ddddd)-?(ddd)", "${1}-${2}"),
+	"ca": newPostcodeFormat("(ldl) ?(dld)", "${1} ${2}"),
+	"ch": newPostcodeFormat("dddd", ""),
+	"cn": newPostcodeFormat("dddddd", ""),
+	"cz": newPostcodeFormat("(ddd) ?(dd)", "${1} ${2}"),
+	"de": newPostcodeFormat("ddddd", ""),
+	"dk": newPostcodeFormat("dddd", ""),
+	"es": newPostcodeFormat("ddddd", ""),
+	"fi": newPostcodeFormat("ddddd", ""),
+	"fr": newPostcodeFormat("ddddd", ""),
+	"gb": newPostcodeFormat("(l?ld[A-Z0-9]?) ?(dll)", "${1} ${2}"),
+	"in": newPostcodeFormat("(ddd) ?(ddd)", "${1}${2}"),
+	"it": newPostcodeFormat("ddddd", ""),
+	"jp": newPostcodeFormat("(ddd)-?(dddd)", "${1}-${2}"),
+	"kr": newPostcodeFormat("ddddd", ""),
+	"mx": newPostcodeFormat("ddddd", ""),
+	"nl": newPostcodeFormat("(dddd) ?(ll)", "${1} ${2}"),
+	"no": newPostcodeFormat("dddd", ""),
+	"pl": newPostcodeFormat("(dd)-?(ddd)", "${1}-${2}"),
+	"pt": newPostcodeFormat("(dddd)-?(ddd)", "${1}-${2}"),
+	"ru": newPostcodeFormat("dddddd", ""),
+	"se": newPostcodeFormat("(ddd) ?(dd)", "${1} ${2}"),
+	"us": newPostcodeFormat("ddddd", ""),
+}
+
+// postcodeCountries postcodeFormats 的国家代码（有序，保证识别结果稳定）。
+var postcodeCountries = func() []string {
+	out := make([]string, 0, len(postcodeFormats))
+	for cc := range postcodeFormats {
+		out = append(out, cc)
+	}
+	sort.Strings(out)
+	return out
+}()
+
+// postcodeCandidate 识别出的邮编：国家代码（空表示不限国家）与规范写法。
+type postcodeCandidate struct {
+	country  string
+	postcode string
+}
+
+// matchPostcode 按国家格式识别邮编；ccodes 为空时尝试全部已知格式。
+// lenient 为真（结构化 postalcode）时，无格式命中也按输入原样（大写、压缩空白）作为不限国家的候选。
+func matchPostcode(s string, ccodes []string, lenient bool) []postcodeCandidate {
+	s = strings.ToUpper(strings.Join(strings.Fields(s), " "))
+	if s == "" {
+		return nil
+	}
+	countries := ccodes
+	if len(countries) == 0 {
+		countries = postcodeCountries
+	}
+	var out []postcodeCandidate
+	for _, cc := range countries {
+		f, ok := postcodeFormats[cc]
+		if !ok || !f.re.MatchString(s) {
+			continue
+		}
+		out = append(out, postcodeCandidate{country: cc, postcode: f.re.ReplaceAllString(s, f.output)})
+	}
+	if len(out) == 0 && lenient {
+		out = append(out, postcodeCandidate{postcode: s})
+	}
+	return out
+}
+
+// compactPostcodes 候选邮编去空白后的写法（用于匹配 placex.postcode），已去重。
+func compactPostcodes(cands []postcodeCandidate) []string {
+	var out []string
+	seen := map[string]struct{}{}
+	for _, c := range cands {
+		pc := normalizePostcode(c.postcode)
+		if _, ok := seen[pc]; ok {
+			continue
+		}
+		seen[pc] = struct{}{}
+		out = append(out, pc)
+	}
+	return out
+}
+
+// splitPostcode 识别整段短语或短语首尾一至两个词构成的邮编（如 "SW1A 1AA"、"10115 Berlin"、"London SW1A 1AA"），
+// 返回去掉邮编后的短语（ok=false 表示短语只剩邮编）。
+// 短语开头的纯数字更常见的是门牌（"1600 Pennsylvania Avenue"），仅在限定了国家时才视作邮编。
+func (ph queryPhrase) splitPostcode(ccodes []string) (queryPhrase, []postcodeCandidate, bool) {
+	words := strings.Fields(ph.raw)
+	if cands := matchPostcode(ph.raw, ccodes, false); len(cands) > 0 {
+		return queryPhrase{}, cands, false
+	}
+	for _, n := range []int{2, 1} {
+		if len(words) <= n {
+			continue
+		}
+		for i, split := range [][2][]string{{words[:n], words[n:]}, {words[len(words)-n:], words[:len(words)-n]}} {
+			text := strings.Join(split[0], " ")
+			if i == 0 && len(ccodes) == 0 && isBareNumber(text) {
+				continue
+			}
+			cands := matchPostcode(text, ccodes, false)
+			if len(cands) == 0 {
+				continue
+			}
+			if rest, ok := newPhrase(ph.kind, strings.Join(split[1], " ")); ok {
+				return rest, cands, true
+			}
+		}
+	}
+	return ph, nil, true
+}
+
+// isBareNumber 是否仅由数字（及空白）组成。
+func isBareNumber(s string) bool {
+	return strings.Trim(s, "0123456789 ") == "" && strings.TrimSpace(s) != ""
+}
+
+// isPostcodeOf 门牌号与某个候选邮编是否为同一个词。
+func isPostcodeOf(housenumber string, cands []postcodeCandidate) bool {
+	for _, c := range cands {
+		if normalizePostcode(c.postcode) == strings.ToUpper(housenumber) {
+			return true
+		}
+	}
+	return false
+}
+
+// postcodeRadius 邮编区域的外扩半径（度）：完整邮编（rank_search>=25）最小，邮编分区依次增大。
+func postcodeRadius(alias string) string {
+	return `(CASE WHEN ` + alias + `.rank_search >= 25 THEN 0.005
+              WHEN ` + alias + `.rank_search >= 21 THEN 0.02
+              ELSE 0.05 END)`
+}
+
+// postcodeColumns location_postcode 的通用列（顺序须与 scanPlace 一致）：
+// 对象为 place/postcode，名称标签为 ref=邮编；边界框为外扩后的邮编区域；重要性取 Nominatim 的缺省值 0.40001 - rank_search/75。
+func postcodeColumns(alias, geoJSON string) string {
+	a := alias + "."
+	area := "ST_Expand(" + a + "geometry, " + postcodeRadius(alias) + ")"
+	return a + `place_id, 0::bigint AS osm_id, '' AS osm_type, 'place' AS class, 'postcode' AS type,
+       ` + a + `postcode AS name,
+       COALESCE(ST_Y(ST_Centroid(` + a + `geometry)), 0) AS lat,
+       COALESCE(ST_X(ST_Centroid(` + a + `geometry)), 0) AS lon,
+       0.40001 - COALESCE(` + a + `rank_search, 25) / 75.0 AS importance,
+       COALESCE(ST_YMin(` + area + `), 0) AS south,
+       COALESCE(ST_YMax(` + area + `), 0) AS north,
+       COALESCE(ST_XMin(` + area + `), 0) AS west,
+       COALESCE(ST_XMax(` + area + `), 0) AS east,
+       json_build_object('ref', ` + a + `postcode)::text AS name_json,
+       '{}' AS extratags_json,
+       ` + geoJSON + ` AS polygon_geojson,
+       COALESCE(` + a + `rank_address, 5) AS rank_address,
+       COALESCE(` + a + `rank_search, 25) AS rank_search,
+       '' AS housenumber,
+       ` + a + `postcode AS postcode,
+       COALESCE(` + a + `country_code, '') AS country_code`
+}
+
+// postcodeFilter 候选邮编的匹配条件：同一规范写法的候选合并为一组国家代码。
+func postcodeFilter(alias string, cands []postcodeCandidate, args *sqlArgs) string {
+	byCode := map[string][]string{}
+	var order []string
+	anyCountry := map[string]bool{}
+	for _, c := range cands {
+		if _, ok := byCode[c.postcode]; !ok {
+			order = append(order, c.postcode)
+			byCode[c.postcode] = nil
+		}
+		if c.country == "" {
+			anyCountry[c.postcode] = true
+		} else {
+			byCode[c.postcode] = append(byCode[c.postcode], c.country)
+		}
+	}
+	conds := make([]string, 0, len(order))
+	for _, pc := range order {
+		cond := alias + ".postcode = " + args.add(pc)
+		if !anyCountry[pc] {
+			cond += " AND " + alias + ".country_code = ANY(" + args.add(pqArray(byCode[pc])) + "::text[])"
+		}
+		conds = append(conds, "("+cond+")")
+	}
+	return "(" + strings.Join(conds, " OR ") + ")"
+}
+
+// postcodeLayers 是否允许返回邮编结果：未指定 layer 时允许，否则需包含 address 或 postcode；featuretype 须为空。
+func postcodeLayers(p biz.SearchParams) bool {
+	if strings.TrimSpace(p.FeatureType) != "" {
+		return false
+	}
+	if len(p.Layers) == 0 {
+		return true
+	}
+	for _, l := range p.Layers {
+		switch strings.ToLower(strings.TrimSpace(l)) {
+		case "address", "postcode":
+			return true
+		}
+	}
+	return false
+}
+
+// searchPostcodes 在 location_postcode 中查找邮编；无结果时回退到邮编边界对象（boundary=postal_code）。
+func (r *searchRepo) searchPostcodes(ctx context.Context, db *sql.DB, p biz.SearchParams, cands []postcodeCandidate) ([]*biz.SearchPlace, error) {
+	if len(cands) == 0 || !postcodeLayers(p) {
+		return []*biz.SearchPlace{}, nil
+	}
+	args := &sqlArgs{}
+	where := postcodeFilter("lp", cands, args)
+	if ccodes := splitCountryCodes(p.CountryCodes); len(ccodes) > 0 {
+		where += " AND lp.country_code = ANY(" + args.add(pqArray(ccodes)) + "::text[])"
+	}
+	hasViewBox := (p.ViewBoxLeft != 0 || p.ViewBoxRight != 0 || p.ViewBoxTop != 0 || p.ViewBoxBottom != 0) && p.ViewBoxLeft < p.ViewBoxRight && p.ViewBoxBottom < p.ViewBoxTop
+	if p.Bounded && hasViewBox {
+		where += " AND lp.geometry && ST_MakeEnvelope(" + args.add(p.ViewBoxLeft) + ", " + args.add(p.ViewBoxBottom) + ", " + args.add(p.ViewBoxRight) + ", " + args.add(p.ViewBoxTop) + ", 4326)"
+	}
+	if len(p.ExcludePlaceIDs) > 0 {
+		where += " AND lp.place_id <> ALL(" + args.add(placeIDArray(p.ExcludePlaceIDs)) + "::bigint[])"
+	}
+	orderBy := "lp.rank_search DESC, lp.place_id"
+	if hasViewBox {
+		center := "ST_SetSRID(ST_Point(" + args.add((p.ViewBoxLeft+p.ViewBoxRight)/2) + ", " + args.add((p.ViewBoxBottom+p.ViewBoxTop)/2) + "), 4326)"
+		orderBy = "lp.geometry <-> " + center + ", " + orderBy
+	}
+	q := `
+SELECT ` + postcodeColumns("lp", geoJSONColumn("lp", p.PolygonGeoJSON, p.PolygonThreshold)) + `
+FROM location_postcode lp
+WHERE ` + where + `
+ORDER BY ` + orderBy + `
+LIMIT ` + args.add(p.Limit) + ` OFFSET ` + args.add(p.Offset)
+	out, err := r.queryPlaces(ctx, db, q, args.args...)
+	if err != nil || len(out) > 0 {
+		return out, err
+	}
+	return r.searchPostcodeBoundaries(ctx, db, p, compactPostcodes(cands))
+}
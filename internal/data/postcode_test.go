@@ -0,0 +1,170 @@
+package data
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMatchPostcode(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		ccodes  []string
+		lenient bool
+		want    []postcodeCandidate
+	}{
+		{name: "empty", s: "  ", lenient: true, want: nil},
+		{name: "gb any country", s: "sw1a 1aa", want: []postcodeCandidate{{country: "gb", postcode: "SW1A 1AA"}}},
+		{name: "gb canonical space", s: "SW1A1AA", ccodes: []string{"gb"}, want: []postcodeCandidate{{country: "gb", postcode: "SW1A 1AA"}}},
+		{name: "nl", s: "1234  ab", ccodes: []string{"nl"}, want: []postcodeCandidate{{country: "nl", postcode: "1234 AB"}}},
+		{name: "br output", s: "01001000", ccodes: []string{"br"}, want: []postcodeCandidate{{country: "br", postcode: "01001-000"}}},
+		{name: "country restricted", s: "10115", ccodes: []string{"de"}, want: []postcodeCandidate{{country: "de", postcode: "10115"}}},
+		{name: "wrong country", s: "10115", ccodes: []string{"gb"}, want: nil},
+		{name: "unknown country", s: "10115", ccodes: []string{"zz"}, want: nil},
+		{name: "no format", s: "abc", want: nil},
+		{name: "lenient fallback", s: "abc 1", lenient: true, want: []postcodeCandidate{{postcode: "ABC 1"}}},
+		{name: "all matching formats", s: "10115", want: []postcodeCandidate{
+			{country: "cz", postcode: "101 15"}, {country: "de", postcode: "10115"}, {country: "es", postcode: "10115"},
+			{country: "fi", postcode: "10115"}, {country: "fr", postcode: "10115"}, {country: "it", postcode: "10115"},
+			{country: "kr", postcode: "10115"}, {country: "mx", postcode: "10115"}, {country: "pl", postcode: "10-115"},
+			{country: "se", postcode: "101 15"}, {country: "us", postcode: "10115"},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchPostcode(tt.s, tt.ccodes, tt.lenient); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("matchPostcode(%q, %v) = %v, want %v", tt.s, tt.ccodes, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitPostcode(t *testing.T) {
+	tests := []struct {
+		name      string
+		s         string
+		ccodes    []string
+		rest      string // 去掉邮编后的短语文本
+		postcodes []string
+		ok        bool
+	}{
+		{name: "whole phrase", s: "SW1A 1AA", postcodes: []string{"SW1A1AA"}, ok: false},
+		{name: "trailing two words", s: "London SW1A 1AA", rest: "london", postcodes: []string{"SW1A1AA"}, ok: true},
+		{name: "leading two words", s: "SW1A 1AA London", rest: "london", postcodes: []string{"SW1A1AA"}, ok: true},
+		{name: "leading number with country", s: "10115 Berlin", ccodes: []string{"de"}, rest: "berlin", postcodes: []string{"10115"}, ok: true},
+		{name: "leading number without country", s: "10115 Berlin", rest: "10115 berlin", ok: true},
+		{name: "trailing number without country", s: "Berlin 10115", rest: "berlin", postcodes: []string{"10115", "10-115"}, ok: true},
+		{name: "house number", s: "1600 Pennsylvania Avenue", rest: "1600 pennsylvania avenue", ok: true},
+		{name: "five digit house number", s: "12345 Main St", rest: "12345 main st", ok: true},
+		{name: "five digit leading with country", s: "12345 Main St", ccodes: []string{"us"}, rest: "main st", postcodes: []string{"12345"}, ok: true},
+		{name: "no postcode", s: "Pennsylvania Avenue", rest: "pennsylvania avenue", ok: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ph, _ := newPhrase(phraseAny, tt.s)
+			rest, cands, ok := ph.splitPostcode(tt.ccodes)
+			if ok != tt.ok || rest.text != tt.rest {
+				t.Fatalf("splitPostcode(%q) = %q, ok=%v; want %q, ok=%v", tt.s, rest.text, ok, tt.rest, tt.ok)
+			}
+			if got := compactPostcodes(cands); !reflect.DeepEqual(got, tt.postcodes) {
+				t.Fatalf("postcodes = %v, want %v", got, tt.postcodes)
+			}
+		})
+	}
+}
+
+func TestParseQuery(t *testing.T) {
+	// reading 为便于比较的解读摘要
+	type reading struct {
+		phrases     []string
+		housenumber string
+		postcodes   []string
+	}
+	tests := []struct {
+		name   string
+		q      string
+		ccodes []string
+		want   []reading
+	}{
+		{
+			name: "house number not postcode",
+			q:    "1600 Pennsylvania Avenue",
+			want: []reading{{phrases: []string{"pennsylvania avenue"}, housenumber: "1600"}},
+		},
+		{
+			name: "five digit house number without country",
+			q:    "12345 Main St",
+			want: []reading{{phrases: []string{"main st"}, housenumber: "12345"}},
+		},
+		{
+			name:   "leading number with country keeps both readings",
+			q:      "12345 Main St",
+			ccodes: []string{"us"},
+			want: []reading{
+				{phrases: []string{"main st"}, postcodes: []string{"12345"}},
+				{phrases: []string{"main st"}, housenumber: "12345"},
+			},
+		},
+		{
+			name:   "postcode and city",
+			q:      "10115 Berlin",
+			ccodes: []string{"de"},
+			want: []reading{
+				{phrases: []string{"berlin"}, postcodes: []string{"10115"}},
+				{phrases: []string{"berlin"}, housenumber: "10115"},
+			},
+		},
+		{
+			name: "trailing number keeps both readings",
+			q:    "Main St 12345",
+			want: []reading{
+				{phrases: []string{"main st"}, postcodes: []string{"12345", "12-345"}},
+				{phrases: []string{"main st"}, housenumber: "12345"},
+			},
+		},
+		{
+			name: "postcode phrase",
+			q:    "221b Baker Street, NW1 6XE",
+			want: []reading{{phrases: []string{"baker street"}, housenumber: "221b", postcodes: []string{"NW16XE"}}},
+		},
+		{
+			name: "house number and later postcode",
+			q:    "1600 Pennsylvania Avenue, Washington, 20500",
+			want: []reading{{phrases: []string{"pennsylvania avenue", "washington"}, housenumber: "1600", postcodes: []string{"20500", "20-500"}}},
+		},
+		{
+			name:   "alternative reading still finds later postcode",
+			q:      "12345 Main St, 20500",
+			ccodes: []string{"us"},
+			want: []reading{
+				{phrases: []string{"main st", "20500"}, postcodes: []string{"12345"}},
+				{phrases: []string{"main st"}, housenumber: "12345", postcodes: []string{"20500"}},
+			},
+		},
+		{
+			name: "postcode only",
+			q:    "SW1A 1AA",
+			want: []reading{{postcodes: []string{"SW1A1AA"}}},
+		},
+		{
+			name: "no numbers",
+			q:    "Berlin, Germany",
+			want: []reading{{phrases: []string{"berlin", "germany"}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []reading
+			for _, rd := range parseQuery(tt.q, tt.ccodes) {
+				r := reading{housenumber: rd.housenumber, postcodes: compactPostcodes(rd.postcodes)}
+				for _, ph := range rd.phrases {
+					r.phrases = append(r.phrases, ph.text)
+				}
+				got = append(got, r)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("parseQuery(%q, %v) = %+v, want %+v", tt.q, tt.ccodes, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,397 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+
+	"nominatim-go/internal/biz"
+)
+
+// 以下实现对齐 Nominatim 逆地理算法（nominatim_api/reverse.py）：
+//   1. max_rank >= 26 时，在固定半径内按真实几何距离查找最近的街道/POI/门牌；
+//      命中街道且需要门牌时，再在街道附近查找挂靠该街道的门牌，没有则按插值线估算门牌；
+//      附近没有街道/POI 时，同样尝试最近的插值线。
+//   2. 未命中则查找包含该点的最小地址面（ST_Contains），并在面内查找等级更细、
+//      且在其等级相关半径内的地名点（place 节点）。
+//   3. 仍未命中则回退到国家。
+// 请求 postcode 图层时，在地址面之前查找区域覆盖该点的最近邮编（location_postcode）。
+
+// reverseStreetDistance 街道/POI 查找半径（度）；reverseHouseDistance 街道附近门牌的查找半径。
+const (
+	reverseStreetDistance = "0.006"
+	reverseHouseDistance  = "0.001"
+)
+
+// reversePoint 查询点表达式（$1=lon, $2=lat）。
+const reversePoint = "ST_SetSRID(ST_Point($1, $2), 4326)"
+
+// reverseLayers 逆地理的图层开关（对齐 Nominatim DataLayer；未指定时为 address+poi；postcode 需显式指定）。
+type reverseLayers struct {
+	address, poi, railway, natural, manmade, postcode bool
+}
+
+func parseReverseLayers(layers []string) reverseLayers {
+	if len(layers) == 0 {
+		return reverseLayers{address: true, poi: true}
+	}
+	var l reverseLayers
+	for _, s := range layers {
+		switch strings.ToLower(strings.TrimSpace(s)) {
+		case "address":
+			l.address = true
+		case "poi":
+			l.poi = true
+		case "railway":
+			l.railway = true
+		case "natural":
+			l.natural = true
+		case "manmade", "man_made":
+			l.manmade = true
+		case "postcode":
+			l.postcode = true
+		}
+	}
+	return l
+}
+
+func (l reverseLayers) hasFeatures() bool {
+	return l.railway || l.natural || l.manmade
+}
+
+// featureFilter 要素图层的 class 过滤（对齐 Nominatim _filter_by_layer）。
+func (l reverseLayers) featureFilter(alias string) string {
+	railway := []string{"'railway'"}
+	natural := []string{"'natural'", "'water'", "'waterway'"}
+	if l.manmade {
+		var exclude []string
+		if !l.railway {
+			exclude = append(exclude, railway...)
+		}
+		if !l.natural {
+			exclude = append(exclude, natural...)
+		}
+		if len(exclude) == 0 {
+			return "true"
+		}
+		return alias + ".class NOT IN (" + strings.Join(exclude, ", ") + ")"
+	}
+	var include []string
+	if l.railway {
+		include = append(include, railway...)
+	}
+	if l.natural {
+		include = append(include, natural...)
+	}
+	return alias + ".class IN (" + strings.Join(include, ", ") + ")"
+}
+
+// placeDiameter 地名点的等级相关查找半径（度），对齐 Nominatim reverse_place_diameter。
+func placeDiameter(rankSearch string) string {
+	return `(CASE WHEN ` + rankSearch + ` <= 4 THEN 5.0
+              WHEN ` + rankSearch + ` <= 8 THEN 1.8
+              WHEN ` + rankSearch + ` <= 12 THEN 0.6
+              WHEN ` + rankSearch + ` <= 17 THEN 0.16
+              WHEN ` + rankSearch + ` <= 18 THEN 0.08
+              WHEN ` + rankSearch + ` <= 19 THEN 0.04
+              ELSE 0.02 END)`
+}
+
+// reverseCandidate 逆地理候选及其与查询点的几何距离。
+type reverseCandidate struct {
+	place    *biz.SearchPlace
+	distance float64
+}
+
+// extraScanner 在 placeColumns 之后追加扫描额外列。
+type extraScanner struct {
+	sc    rowScanner
+	extra []any
+}
+
+func (e extraScanner) Scan(dest ...any) error {
+	return e.sc.Scan(append(dest, e.extra...)...)
+}
+
+// reverseQuery 一次逆地理查询的上下文。
+type reverseQuery struct {
+	db      *sql.DB
+	p       biz.ReverseParams
+	maxRank int
+	layers  reverseLayers
+}
+
+// placeColumns 返回指定别名下的通用列（含按请求生成的多边形 GeoJSON）。
+func (q *reverseQuery) placeColumns(alias string) string {
+	return placeColumns(alias, geoJSONColumn(alias, q.p.PolygonGeoJSON, q.p.PolygonThreshold))
+}
+
+func (q *reverseQuery) queryCandidates(ctx context.Context, query string, args ...any) ([]reverseCandidate, error) {
+	rows, err := q.db.QueryContext(ctx, query, append([]any{q.p.Lon, q.p.Lat}, args...)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []reverseCandidate
+	for rows.Next() {
+		var c reverseCandidate
+		it, err := scanPlace(extraScanner{sc: rows, extra: []any{&c.distance}})
+		if err != nil {
+			return nil, err
+		}
+		c.place = it
+		out = append(out, c)
+	}
+	return out, rows.Err()
+}
+
+func (q *reverseQuery) queryOne(ctx context.Context, query string, args ...any) (*biz.SearchPlace, error) {
+	list, err := q.queryCandidates(ctx, query, args...)
+	if err != nil || len(list) == 0 {
+		return nil, err
+	}
+	return list[0].place, nil
+}
+
+// lookup 按 街道/POI → 地址面 → 国家 的顺序查找。
+func (q *reverseQuery) lookup(ctx context.Context) (*biz.SearchPlace, error) {
+	if q.maxRank >= 26 {
+		it, err := q.lookupStreetPOI(ctx)
+		if err != nil || it != nil {
+			return it, err
+		}
+	}
+	if q.layers.postcode && q.maxRank >= 5 {
+		it, err := q.lookupPostcode(ctx)
+		if err != nil || it != nil {
+			return it, err
+		}
+	}
+	if !q.layers.address {
+		return nil, nil
+	}
+	if q.maxRank >= 5 {
+		it, err := q.lookupArea(ctx)
+		if err != nil || it != nil {
+			return it, err
+		}
+	}
+	if q.maxRank >= 4 {
+		return q.lookupCountry(ctx)
+	}
+	return nil, nil
+}
+
+// lookupStreetPOI 查找半径内最近的街道/POI；若最近对象为面且附近有 POI 节点，则优先返回节点。
+func (q *reverseQuery) lookupStreetPOI(ctx context.Context) (*biz.SearchPlace, error) {
+	var restrict []string
+	if q.layers.address {
+		maxRank := q.maxRank
+		if maxRank > 29 {
+			maxRank = 29
+		}
+		restrict = append(restrict, "p.rank_address BETWEEN 26 AND "+strconv.Itoa(maxRank))
+		if q.maxRank == 30 {
+			restrict = append(restrict, "(p.rank_address = 30 AND (p.housenumber IS NOT NULL OR p.name ? 'addr:housename'))")
+		}
+	}
+	if q.layers.poi && q.maxRank == 30 {
+		restrict = append(restrict, `(p.rank_search = 30 AND p.class NOT IN ('place', 'building')
+        AND ST_GeometryType(p.geometry) NOT IN ('ST_LineString', 'ST_MultiLineString'))`)
+	}
+	if q.layers.hasFeatures() {
+		restrict = append(restrict, "(p.rank_search BETWEEN 26 AND "+strconv.Itoa(q.maxRank)+
+			" AND p.rank_address = 0 AND "+q.layers.featureFilter("p")+")")
+	}
+	if len(restrict) == 0 {
+		return nil, nil
+	}
+	// 半径以常量拼入 SQL，便于规划器估算空间索引的使用
+	query := `
+SELECT ` + q.placeColumns("p") + `,
+       ST_Distance(p.geometry, ` + reversePoint + `) AS distance
+FROM placex p
+WHERE ST_DWithin(p.geometry, ` + reversePoint + `, ` + reverseStreetDistance + `)
+  AND p.indexed_status = 0
+  AND p.linked_place_id IS NULL
+  AND (ST_GeometryType(p.geometry) NOT IN ('ST_Polygon', 'ST_MultiPolygon')
+       OR ST_Distance(p.centroid, ` + reversePoint + `) < ` + reverseStreetDistance + `)
+  AND (` + strings.Join(restrict, " OR ") + `)
+ORDER BY distance
+LIMIT 2`
+	list, err := q.queryCandidates(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		if q.maxRank > 27 && q.layers.address {
+			return q.lookupInterpolation(ctx, 0)
+		}
+		return nil, nil
+	}
+	best := list[0]
+	if best.place.RankSearch > 27 && best.place.OSMType != "node" && best.distance <= 0 && len(list) > 1 {
+		// 点落在面状 POI 内：若紧邻处有 POI 节点，则返回节点
+		if next := list[1]; next.place.RankSearch > 27 && next.place.OSMType == "node" && next.distance < 0.0001 {
+			best = next
+		}
+	}
+	// 命中街道但需要门牌时，查找挂靠该街道的邻近门牌
+	if q.maxRank > 27 && q.layers.address && best.place.RankAddress <= 27 {
+		house, err := q.lookupHousenumber(ctx, best.place.PlaceID)
+		if err != nil {
+			return nil, err
+		}
+		if house != nil {
+			return house, nil
+		}
+		house, err = q.lookupInterpolation(ctx, best.place.PlaceID)
+		if err != nil {
+			return nil, err
+		}
+		if house != nil {
+			return house, nil
+		}
+	}
+	return best.place, nil
+}
+
+// lookupInterpolation 在门牌查找半径内取最近的插值线（streetID>0 时限定所属街道），
+// 按查询点在线上的投影位置估算门牌号，并将位置取整到对应号码处（对齐 Nominatim _interpolated_housenumber/_interpolated_position）。
+func (q *reverseQuery) lookupInterpolation(ctx context.Context, streetID int64) (*biz.SearchPlace, error) {
+	var args []any
+	parent := ""
+	if streetID > 0 {
+		parent = "AND o.parent_place_id = $3"
+		args = append(args, streetID)
+	}
+	query := `
+WITH line AS (
+  SELECT o.place_id, o.osm_id, o.postcode, o.address, o.country_code, o.linegeo, o.startnumber, o.endnumber, o.step,
+         ST_LineLocatePoint(o.linegeo, ` + reversePoint + `) AS position,
+         ST_Distance(o.linegeo, ` + reversePoint + `) AS distance
+  FROM location_property_osmline o
+  WHERE ST_DWithin(o.linegeo, ` + reversePoint + `, ` + reverseHouseDistance + `)
+    AND o.startnumber IS NOT NULL
+    AND o.indexed_status = 0
+    ` + parent + `
+  ORDER BY distance
+  LIMIT 1
+), i AS (
+  SELECT line.*,
+         (startnumber + round((endnumber - startnumber) * position / step) * step)::int AS hnr,
+         CASE WHEN endnumber = startnumber THEN ST_LineInterpolatePoint(linegeo, 0.5)
+              ELSE ST_LineInterpolatePoint(linegeo, LEAST(1, round(position * (endnumber - startnumber) / step) * step / (endnumber - startnumber)))
+         END AS geometry
+  FROM line
+)
+SELECT ` + interpolationColumns("i", geoJSONColumn("i", q.p.PolygonGeoJSON, q.p.PolygonThreshold)) + `, i.distance
+FROM i`
+	return q.queryOne(ctx, query, args...)
+}
+
+// lookupHousenumber 查找街道附近、parent_place_id 指向该街道的门牌。
+func (q *reverseQuery) lookupHousenumber(ctx context.Context, streetID int64) (*biz.SearchPlace, error) {
+	query := `
+SELECT ` + q.placeColumns("p") + `,
+       ST_Distance(p.geometry, ` + reversePoint + `) AS distance
+FROM placex p
+WHERE ST_DWithin(p.geometry, ` + reversePoint + `, ` + reverseHouseDistance + `)
+  AND p.parent_place_id = $3
+  AND p.rank_address = 30
+  AND (p.housenumber IS NOT NULL OR p.name ? 'addr:housename')
+  AND p.indexed_status = 0
+  AND p.linked_place_id IS NULL
+ORDER BY distance
+LIMIT 1`
+	return q.queryOne(ctx, query, streetID)
+}
+
+// lookupArea 查找包含该点的最小地址面，再在面内查找更细等级且在其半径内的地名点。
+func (q *reverseQuery) lookupArea(ctx context.Context) (*biz.SearchPlace, error) {
+	// 内层按等级排序并截断，仅对少量候选执行 ST_Contains
+	query := `
+WITH area AS (
+  SELECT p.*
+  FROM placex p
+  WHERE p.rank_search BETWEEN 5 AND $3
+    AND ST_Intersects(p.geometry, ` + reversePoint + `)
+    AND ST_GeometryType(p.geometry) IN ('ST_Polygon', 'ST_MultiPolygon')
+    AND p.rank_address BETWEEN 4 AND 25
+    AND p.type != 'postcode'
+    AND p.name IS NOT NULL
+    AND p.indexed_status = 0
+    AND p.linked_place_id IS NULL
+  ORDER BY p.rank_search DESC
+  LIMIT 50
+)
+SELECT ` + q.placeColumns("area") + `, 0::float8 AS distance
+FROM area
+WHERE ST_Contains(area.geometry, ` + reversePoint + `)
+ORDER BY area.rank_search DESC
+LIMIT 1`
+	area, err := q.queryOne(ctx, query, q.maxRank)
+	if err != nil || area == nil || area.RankSearch >= q.maxRank {
+		return area, err
+	}
+	query = `
+WITH places AS (
+  SELECT p.*, ST_Distance(p.geometry, ` + reversePoint + `) AS distance
+  FROM placex p
+  WHERE p.rank_search > $3
+    AND p.rank_search <= $4
+    AND p.osm_type = 'N'
+    AND p.rank_address BETWEEN 4 AND 25
+    AND p.type != 'postcode'
+    AND p.name IS NOT NULL
+    AND p.indexed_status = 0
+    AND p.linked_place_id IS NULL
+    AND ST_DWithin(p.geometry, ` + reversePoint + `, ` + placeDiameter("p.rank_search") + `)
+  ORDER BY p.rank_search DESC
+  LIMIT 50
+)
+SELECT ` + q.placeColumns("places") + `, places.distance
+FROM places
+JOIN placex outer_area ON ST_Contains(outer_area.geometry, places.geometry)
+WHERE outer_area.place_id = $5
+  AND places.distance < ` + placeDiameter("places.rank_search") + `
+ORDER BY places.rank_search DESC, places.distance
+LIMIT 1`
+	place, err := q.queryOne(ctx, query, area.RankSearch, q.maxRank, area.PlaceID)
+	if err != nil || place == nil {
+		return area, err
+	}
+	return place, nil
+}
+
+// lookupPostcode 查找外扩区域覆盖该点、且精度不超过 maxRank 的最近邮编。
+func (q *reverseQuery) lookupPostcode(ctx context.Context) (*biz.SearchPlace, error) {
+	query := `
+SELECT ` + postcodeColumns("lp", geoJSONColumn("lp", q.p.PolygonGeoJSON, q.p.PolygonThreshold)) + `,
+       ST_Distance(lp.geometry, ` + reversePoint + `) AS distance
+FROM location_postcode lp
+WHERE ST_DWithin(lp.geometry, ` + reversePoint + `, 0.05)
+  AND ST_DWithin(lp.geometry, ` + reversePoint + `, ` + postcodeRadius("lp") + `)
+  AND lp.rank_search <= $3
+ORDER BY lp.rank_search DESC, distance
+LIMIT 1`
+	return q.queryOne(ctx, query, q.maxRank)
+}
+
+// lookupCountry 经 country_osm_grid 确定国家代码，返回对应的国家对象。
+func (q *reverseQuery) lookupCountry(ctx context.Context) (*biz.SearchPlace, error) {
+	query := `
+SELECT ` + q.placeColumns("p") + `, 0::float8 AS distance
+FROM placex p
+WHERE p.rank_search = 4
+  AND p.linked_place_id IS NULL
+  AND p.country_code = (
+    SELECT g.country_code FROM country_osm_grid g
+    WHERE ST_Contains(g.geometry, ` + reversePoint + `)
+    ORDER BY g.area
+    LIMIT 1)
+ORDER BY p.importance DESC NULLS LAST
+LIMIT 1`
+	return q.queryOne(ctx, query)
+}
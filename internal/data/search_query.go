@@ -0,0 +1,359 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"nominatim-go/internal/biz"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// 以下实现对齐 Nominatim ICU tokenizer 的读路径：
+//   - word(word_id, word_token, type, word, info)：W=完整名称，w=名称片段（单词），H=门牌，P=邮编
+//   - search_name(place_id, name_vector, nameaddress_vector, ...)：名称与地址的 token 向量
+// 查询按逗号拆分为若干短语（结构化查询则每个组件一个短语），第一个短语视为名称，其余为地址。
+// 注意：Go 侧无法复刻 ICU 音译（如 CJK→拉丁），此时仅能依赖 word 列（未音译的规范化全称）精确匹配完整名称。
+
+// phraseKind 短语类型：自由文本或结构化查询的某个组件。
+type phraseKind int
+
+const (
+	phraseAny phraseKind = iota
+	phraseAmenity
+	phraseStreet
+	phraseCity
+	phraseCounty
+	phraseState
+	phraseCountry
+)
+
+// rankRange 返回短语类型对应的等级范围；自由文本不限制。
+func (k phraseKind) rankRange() (int, int, bool) {
+	switch k {
+	case phraseAmenity:
+		return 28, 30, true
+	case phraseStreet:
+		return 26, 27, true
+	case phraseCity:
+		return 13, 20, true
+	case phraseCounty:
+		return 10, 12, true
+	case phraseState:
+		return 5, 9, true
+	case phraseCountry:
+		return 4, 4, true
+	default:
+		return 0, 0, false
+	}
+}
+
+// queryPhrase 查询中以逗号分隔的一段。
+type queryPhrase struct {
+	kind  phraseKind // 短语类型
+	text  string     // 归一化后的整段文本（用于完整名称 token）
+	raw   string     // 仅小写/NFC 的整段文本（用于匹配 word.word）
+	terms []string   // 拆分后的词（用于名称片段 token）
+}
+
+// wordTokens 为 word 表查询结果。
+type wordTokens struct {
+	full    map[string]int64  // 短语文本 -> 完整名称 word_id
+	partial map[string]int64  // 单词 -> 名称片段 word_id
+	country map[string]string // 短语文本 -> 国家代码
+}
+
+var housenumberPattern = regexp.MustCompile(`^[0-9]+[a-z]?$`)
+
+// stripMarks 去除变音符号（NFKD 后删除 Mn 类字符）；Transformer 有状态，每次新建。
+func stripMarks() transform.Transformer {
+	return transform.Chain(norm.NFKD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+}
+
+// normalizeText 近似 ICU 归一化：小写、去变音、标点视为空白、压缩空白。
+func normalizeText(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if t, _, err := transform.String(stripMarks(), s); err == nil {
+		s = t
+	}
+	s = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return ' '
+	}, s)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// queryReading 自由文本的一种解读。
+type queryReading struct {
+	phrases     []queryPhrase       // 去掉邮编与门牌后的短语
+	housenumber string              // 第一个短语中的门牌号
+	postcodes   []postcodeCandidate // 识别出的邮编
+}
+
+// parseQuery 将自由文本拆分为短语，先按国家格式（ccodes）识别一个邮编，再尝试从第一个短语中分离门牌号。
+// 第一个短语中被识别为邮编的词同样可以是门牌号（"12345 Main St"）时返回两种解读：先按邮编，再按门牌。
+func parseQuery(q string, ccodes []string) []queryReading {
+	rd, ambiguous := parseReading(q, ccodes, true)
+	if !ambiguous {
+		return []queryReading{rd}
+	}
+	alt, _ := parseReading(q, ccodes, false)
+	return []queryReading{rd, alt}
+}
+
+// parseReading 按一种解读拆分自由文本；firstPostcode 为假时不在第一个短语中识别邮编。
+// ambiguous 表示第一个短语中识别出的邮编也可作为门牌号。
+func parseReading(q string, ccodes []string, firstPostcode bool) (rd queryReading, ambiguous bool) {
+	for i, part := range strings.Split(q, ",") {
+		ph, ok := newPhrase(phraseAny, part)
+		if !ok {
+			continue
+		}
+		if len(rd.postcodes) == 0 && (i > 0 || firstPostcode) {
+			whole := ph
+			if ph, rd.postcodes, ok = ph.splitPostcode(ccodes); !ok {
+				continue
+			}
+			if i == 0 && len(rd.postcodes) > 0 {
+				_, hn := whole.splitHousenumber()
+				ambiguous = hn != "" && isPostcodeOf(hn, rd.postcodes)
+			}
+		}
+		if i == 0 {
+			ph, rd.housenumber = ph.splitHousenumber()
+		}
+		rd.phrases = append(rd.phrases, ph)
+	}
+	return rd, ambiguous
+}
+
+// parseStructured 将结构化参数按从具体到宽泛的顺序转为短语，最具体者作为名称。
+func parseStructured(p biz.SearchParams) ([]queryPhrase, string) {
+	var phrases []queryPhrase
+	housenumber := ""
+	for _, c := range []struct {
+		kind  phraseKind
+		value string
+	}{
+		{phraseAmenity, p.Amenity},
+		{phraseStreet, p.Street},
+		{phraseCity, p.City},
+		{phraseCounty, p.County},
+		{phraseState, p.State},
+		{phraseCountry, p.Country},
+	} {
+		ph, ok := newPhrase(c.kind, c.value)
+		if !ok {
+			continue
+		}
+		if c.kind == phraseStreet {
+			ph, housenumber = ph.splitHousenumber()
+		}
+		phrases = append(phrases, ph)
+	}
+	return phrases, housenumber
+}
+
+func newPhrase(kind phraseKind, s string) (queryPhrase, bool) {
+	text := normalizeText(s)
+	if text == "" {
+		return queryPhrase{}, false
+	}
+	return queryPhrase{
+		kind:  kind,
+		text:  text,
+		raw:   strings.ToLower(norm.NFC.String(strings.TrimSpace(s))),
+		terms: strings.Fields(text),
+	}, true
+}
+
+// splitHousenumber 仅当短语还有其他词时，才将纯数字（可带字母后缀）的词视为门牌。
+func (ph queryPhrase) splitHousenumber() (queryPhrase, string) {
+	if len(ph.terms) < 2 {
+		return ph, ""
+	}
+	housenumber := ""
+	kept := make([]string, 0, len(ph.terms))
+	for _, t := range ph.terms {
+		if housenumber == "" && housenumberPattern.MatchString(t) {
+			housenumber = t
+			continue
+		}
+		kept = append(kept, t)
+	}
+	if housenumber == "" {
+		return ph, ""
+	}
+	ph.terms = kept
+	ph.text = strings.Join(kept, " ")
+	ph.raw = strings.TrimSpace(strings.Replace(" "+ph.raw+" ", " "+housenumber+" ", " ", 1))
+	return ph, housenumber
+}
+
+// lookupWords 一次性查询短语所需的全部 word_id。
+func lookupWords(ctx context.Context, db *sql.DB, phrases []queryPhrase) (*wordTokens, error) {
+	out := &wordTokens{full: map[string]int64{}, partial: map[string]int64{}, country: map[string]string{}}
+	seen := map[string]struct{}{}
+	var tokens, raws []string
+	for _, ph := range phrases {
+		for _, t := range append([]string{ph.text}, ph.terms...) {
+			if _, ok := seen[t]; !ok && t != "" {
+				seen[t] = struct{}{}
+				tokens = append(tokens, pqQuote(t))
+			}
+		}
+		if ph.raw != "" {
+			raws = append(raws, pqQuote(ph.raw))
+		}
+	}
+	if len(tokens) == 0 {
+		return out, nil
+	}
+	q := `
+SELECT word_id, word_token, COALESCE(word, ''), type
+FROM word
+WHERE (word_token = ANY($1::text[]) AND type IN ('W', 'w', 'C'))
+   OR (type = 'W' AND word = ANY($2::text[]))`
+	rows, err := db.QueryContext(ctx, q, pqArray(tokens), pqArray(raws))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			id               int64
+			token, word, typ string
+		)
+		if err := rows.Scan(&id, &token, &word, &typ); err != nil {
+			return nil, err
+		}
+		switch typ {
+		case "w":
+			out.partial[token] = id
+		case "W":
+			out.full[token] = id
+			for _, ph := range phrases {
+				if ph.raw == word {
+					out.full[ph.text] = id
+				}
+			}
+		case "C":
+			// 国家名称 token：word 列为国家代码
+			out.country[token] = strings.ToLower(word)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// partialIDs 返回短语全部单词的片段 token；任一单词缺失则返回 nil。
+func (w *wordTokens) partialIDs(ph queryPhrase) []string {
+	ids := make([]string, 0, len(ph.terms))
+	for _, t := range ph.terms {
+		id, ok := w.partial[t]
+		if !ok {
+			return nil
+		}
+		ids = append(ids, strconv.FormatInt(id, 10))
+	}
+	return ids
+}
+
+// sqlArgs 按顺序收集 SQL 参数并返回占位符。
+type sqlArgs struct {
+	args []any
+}
+
+func (a *sqlArgs) add(v any) string {
+	a.args = append(a.args, v)
+	return "$" + strconv.Itoa(len(a.args))
+}
+
+// tokenMatch 返回短语在某个向量列上的匹配候选（片段全集或完整名称）。
+func (w *wordTokens) tokenMatch(ph queryPhrase, column string, args *sqlArgs) []string {
+	var alts []string
+	if partials := w.partialIDs(ph); len(partials) > 0 {
+		alts = append(alts, column+" @> "+args.add(pqArray(partials))+"::int[]")
+	}
+	if id, ok := w.full[ph.text]; ok {
+		alts = append(alts, column+" @> "+args.add(pqArray([]string{strconv.FormatInt(id, 10)}))+"::int[]")
+	}
+	return alts
+}
+
+// buildTokenFilter 构造 search_name 上的匹配条件与“完整名称命中”排序表达式。
+// 第一个短语须命中 name_vector，其余短语须命中 nameaddress_vector；
+// 当查询只有一个多词短语时，额外允许名称词与地址词混排（如 "Main Street Springfield"）。
+// 结构化组件各自约束等级：名称组件限制结果的 search_rank，地址组件要求对应等级的地址行命中。
+func buildTokenFilter(phrases []queryPhrase, words *wordTokens, args *sqlArgs) (string, string, bool) {
+	var conds []string
+	exact := "false"
+	for i, ph := range phrases {
+		minRank, maxRank, ranked := ph.kind.rankRange()
+		if i == 0 {
+			alts := words.tokenMatch(ph, "s.name_vector", args)
+			if id, ok := words.full[ph.text]; ok {
+				exact = "s.name_vector @> " + args.add(pqArray([]string{strconv.FormatInt(id, 10)})) + "::int[]"
+			}
+			partials := words.partialIDs(ph)
+			if ph.kind == phraseAny && len(phrases) == 1 && len(ph.terms) > 1 && len(partials) > 0 {
+				first := args.add(pqArray(partials[:1]))
+				last := args.add(pqArray(partials[len(partials)-1:]))
+				alts = append(alts, "((s.name_vector @> "+first+"::int[] OR s.name_vector @> "+last+"::int[])"+
+					" AND (s.name_vector || s.nameaddress_vector) @> "+args.add(pqArray(partials))+"::int[])")
+			}
+			if len(alts) == 0 {
+				return "", "", false
+			}
+			cond := "(" + strings.Join(alts, " OR ") + ")"
+			if ranked {
+				cond += " AND s.search_rank BETWEEN " + args.add(minRank) + " AND " + args.add(maxRank)
+			}
+			conds = append(conds, cond)
+			continue
+		}
+		// 国家名称可直接解析为国家代码
+		if cc, ok := words.country[ph.text]; ok && (ph.kind == phraseAny || ph.kind == phraseCountry) {
+			conds = append(conds, "s.country_code = "+args.add(cc))
+			continue
+		}
+		alts := words.tokenMatch(ph, "s.nameaddress_vector", args)
+		if len(alts) == 0 {
+			return "", "", false
+		}
+		cond := "(" + strings.Join(alts, " OR ") + ")"
+		if ranked {
+			// 地址组件：要求存在等级落在组件范围内、且名称命中的地址行
+			addrAlts := words.tokenMatch(ph, "a.name_vector", args)
+			cond += `
+    AND EXISTS (
+      SELECT 1 FROM place_addressline pa
+      JOIN search_name a ON a.place_id = pa.address_place_id
+      WHERE pa.place_id = s.place_id AND pa.isaddress
+        AND a.address_rank BETWEEN ` + args.add(minRank) + ` AND ` + args.add(maxRank) + `
+        AND (` + strings.Join(addrAlts, " OR ") + `))`
+		}
+		conds = append(conds, cond)
+	}
+	if len(conds) == 0 {
+		return "", "", false
+	}
+	return strings.Join(conds, " AND "), exact, true
+}
+
+// pqQuote 将字符串转为 PostgreSQL 数组字面量中的带引号元素。
+func pqQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + s + `"`
+}
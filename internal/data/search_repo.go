@@ -24,8 +24,93 @@ func (r *searchRepo) sqlDB() *sql.DB {
 	return r.data.SQLDB()
 }
 
+func (r *searchRepo) isPostgres() bool {
+	switch r.data.conf.Database.Driver {
+	case "postgres", "postgresql", "pgx":
+		return true
+	}
+	return false
+}
+
+// placeColumns 返回 placex 的通用查询列（顺序须与 scanPlace 一致）。
+func placeColumns(alias, geoJSON string) string {
+	a := alias + "."
+	return a + `place_id, ` + a + `osm_id, ` + a + `osm_type, ` + a + `class, ` + a + `type,
+       COALESCE(` + a + `name->'name', '') AS name,
+       COALESCE(ST_Y(` + a + `centroid), 0) AS lat,
+       COALESCE(ST_X(` + a + `centroid), 0) AS lon,
+       COALESCE(` + a + `importance, 0) AS importance,
+       COALESCE(ST_YMin(` + a + `geometry), 0) AS south,
+       COALESCE(ST_YMax(` + a + `geometry), 0) AS north,
+       COALESCE(ST_XMin(` + a + `geometry), 0) AS west,
+       COALESCE(ST_XMax(` + a + `geometry), 0) AS east,
+       COALESCE(hstore_to_json(` + a + `name)::text, '{}') AS name_json,
+       COALESCE(hstore_to_json(` + a + `extratags)::text, '{}') AS extratags_json,
+       ` + geoJSON + ` AS polygon_geojson,
+       COALESCE(` + a + `rank_address, 0) AS rank_address,
+       COALESCE(` + a + `rank_search, 0) AS rank_search,
+       COALESCE(` + a + `housenumber, '') AS housenumber,
+       COALESCE(` + a + `postcode, '') AS postcode,
+       COALESCE(` + a + `country_code, '') AS country_code`
+}
+
+// placeColumnNames 为 placeColumns 的输出列名，用于外层 SELECT。
+const placeColumnNames = `place_id, osm_id, osm_type, class, type, name, lat, lon, importance,
+       south, north, west, east, name_json, extratags_json, polygon_geojson,
+       rank_address, rank_search, housenumber, postcode, country_code`
+
+// geoJSONColumn 返回多边形 GeoJSON 列表达式；未请求时返回空串常量。
+func geoJSONColumn(alias string, enabled bool, threshold float64) string {
+	if !enabled {
+		return "''"
+	}
+	if threshold > 0 {
+		return "COALESCE(ST_AsGeoJSON(ST_Simplify(" + alias + ".geometry, " + strconv.FormatFloat(threshold, 'f', -1, 64) + "), 6)::text, '')"
+	}
+	return "COALESCE(ST_AsGeoJSON(" + alias + ".geometry, 6)::text, '')"
+}
+
+// rowScanner 兼容 *sql.Row 与 *sql.Rows。
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanPlace 扫描 placeColumns 对应的一行。
+func scanPlace(sc rowScanner) (*biz.SearchPlace, error) {
+	var it biz.SearchPlace
+	var osmType string
+	var nameJSON, extratagsJSON, poly string
+	if err := sc.Scan(&it.PlaceID, &it.OSMID, &osmType, &it.Category, &it.Type, &it.Name, &it.Lat, &it.Lon, &it.Importance, &it.BBoxSouth, &it.BBoxNorth, &it.BBoxWest, &it.BBoxEast, &nameJSON, &extratagsJSON, &poly,
+		&it.RankAddress, &it.RankSearch, &it.HouseNumber, &it.Postcode, &it.CountryCode); err != nil {
+		return nil, err
+	}
+	it.OSMType = osmTypeName(osmType)
+	_ = json.Unmarshal([]byte(nameJSON), &it.NameDetails)
+	_ = json.Unmarshal([]byte(extratagsJSON), &it.ExtraTags)
+	it.PolygonGeoJSON = poly
+	return &it, nil
+}
+
+// osmTypeName 将 N/W/R 转为 node/way/relation。
+func osmTypeName(t string) string {
+	switch strings.ToUpper(t) {
+	case "N":
+		return "node"
+	case "W":
+		return "way"
+	case "R":
+		return "relation"
+	default:
+		return strings.ToLower(t)
+	}
+}
+
+// SearchPlaces 基于 word/search_name 的 token 检索，邮编查询走 location_postcode：
+// 结构化 postalcode 与其他组件同时出现时仅排除邮编明确不符的对象，单独出现时直接查邮编；
+// 自由文本中按国家格式识别出邮编时，先查邮编一致的对象（如 "Baker Street, NW1 6XE"），
+// 未命中时再按门牌解读开头的数字（如 "12345 Main St"），仍未命中或只有邮编时返回邮编本身。
 func (r *searchRepo) SearchPlaces(ctx context.Context, p biz.SearchParams) ([]*biz.SearchPlace, error) {
-	if !(r.data.conf.Database.Driver == "postgres" || r.data.conf.Database.Driver == "postgresql" || r.data.conf.Database.Driver == "pgx") {
+	if !r.isPostgres() {
 		return []*biz.SearchPlace{}, nil
 	}
 	db := r.sqlDB()
@@ -33,114 +118,98 @@ func (r *searchRepo) SearchPlaces(ctx context.Context, p biz.SearchParams) ([]*b
 		return []*biz.SearchPlace{}, nil
 	}
 
-	var ccodes []string
-	for _, c := range strings.Split(p.CountryCodes, ",") {
-		c = strings.TrimSpace(strings.ToLower(c))
-		if c != "" {
-			ccodes = append(ccodes, c)
+	ccodes := splitCountryCodes(p.CountryCodes)
+	if p.IsStructured() {
+		phrases, housenumber := parseStructured(p)
+		postcodes := matchPostcode(p.PostalCode, ccodes, true)
+		if len(phrases) == 0 {
+			return r.searchPostcodes(ctx, db, p, postcodes)
 		}
+		return r.searchTokens(ctx, db, p, phrases, housenumber, compactPostcodes(postcodes), false)
 	}
-
-	geoJSONSelect := "''"
-	if p.PolygonGeoJSON {
-		if p.PolygonThreshold > 0 {
-			geoJSONSelect = "COALESCE(ST_AsGeoJSON(ST_Simplify(polygon, " + strconv.FormatFloat(p.PolygonThreshold, 'f', -1, 64) + "), 6)::text, '')"
-		} else {
-			geoJSONSelect = "COALESCE(ST_AsGeoJSON(polygon, 6)::text, '')"
+	var postcodes []postcodeCandidate
+	for _, rd := range parseQuery(p.Q, ccodes) {
+		if postcodes == nil {
+			postcodes = rd.postcodes
 		}
-	}
-
-	// DISTINCT ON 去重设置
-	distinctOn := ""
-	orderPrefix := ""
-	if p.Dedupe {
-		distinctOn = "DISTINCT ON (osm_type, osm_id)"
-		orderPrefix = "osm_type, osm_id, "
-	}
-
-	// 近距离去重逻辑：当 dedupe 启用时，对相同 class/type 且栅格化质心一致者仅保留重要性高者
-	gridExpr := "ST_SnapToGrid(centroid, 0.0005)"
-	if !p.Dedupe {
-		gridExpr = "centroid"
-	}
-	base := `
-WITH base AS (
-  SELECT
-    place_id, osm_id, osm_type, class, type,
-    ` + gridExpr + ` AS gcentroid,
-    COALESCE(name->'name','') AS name,
-    COALESCE(ST_Y(centroid), 0) AS lat,
-    COALESCE(ST_X(centroid), 0) AS lon,
-    COALESCE(importance, 0) AS importance,
-    COALESCE(ST_YMin(bbox), 0) AS south,
-    COALESCE(ST_YMax(bbox), 0) AS north,
-    COALESCE(ST_XMin(bbox), 0) AS west,
-    COALESCE(ST_XMax(bbox), 0) AS east,
-    COALESCE(hstore_to_json(name)::text, '{}') AS name_json,
-    COALESCE(hstore_to_json(extratags)::text, '{}') AS extratags_json,
-    ` + geoJSONSelect + ` AS polygon_geojson
-  FROM placex
-  WHERE (name ? 'name') AND (name->'name' ILIKE $1)
-), deduped AS (
-  SELECT ` + distinctOn + ` *
-  FROM base
-  ` + func() string {
-		if p.Dedupe {
-			return "ORDER BY class, type, gcentroid, importance DESC NULLS LAST, place_id DESC"
+		if len(rd.phrases) == 0 {
+			continue
 		}
-		return ""
-	}() + `
-)
-SELECT place_id, osm_id, osm_type, class, type,
-       name, lat, lon, importance, south, north, west, east, name_json, extratags_json, polygon_geojson
-FROM deduped
-`
-	args := []any{"%" + p.Q + "%"}
-	argIdx := 2
-	if len(ccodes) > 0 {
-		placeholders := make([]string, 0, len(ccodes))
-		for _, c := range ccodes {
-			placeholders = append(placeholders, "$"+strconv.Itoa(argIdx))
-			args = append(args, c)
-			argIdx++
+		out, err := r.searchTokens(ctx, db, p, rd.phrases, rd.housenumber, compactPostcodes(rd.postcodes), len(rd.postcodes) > 0)
+		if err != nil || len(out) > 0 || len(postcodes) == 0 {
+			return out, err
 		}
-		base += " AND country_code IN (" + strings.Join(placeholders, ",") + ")"
 	}
+	if len(postcodes) == 0 {
+		return []*biz.SearchPlace{}, nil
+	}
+	return r.searchPostcodes(ctx, db, p, postcodes)
+}
+
+// searchTokens 基于 word/search_name 的 token 检索：
+// 查询先归一化为短语与单词，经 word 表解析为 word_id，再与 search_name 的名称/地址向量求交，
+// 命中后回表 placex 并应用 biz.SearchParams 中的各类过滤。
+// postcodes 为去空白的候选邮编：strict 时要求对象邮编一致，否则仅排除邮编明确不符的对象。
+func (r *searchRepo) searchTokens(ctx context.Context, db *sql.DB, p biz.SearchParams, phrases []queryPhrase, housenumber string, postcodes []string, strict bool) ([]*biz.SearchPlace, error) {
+	if len(phrases) == 0 {
+		return []*biz.SearchPlace{}, nil
+	}
+	words, err := lookupWords(ctx, db, phrases)
+	if err != nil {
+		return nil, err
+	}
+	args := &sqlArgs{}
+	tokenFilter, exactExpr, ok := buildTokenFilter(phrases, words, args)
+	if !ok {
+		// 存在无法解析的词，不可能有匹配
+		return []*biz.SearchPlace{}, nil
+	}
+
+	if ccodes := splitCountryCodes(p.CountryCodes); len(ccodes) > 0 {
+		tokenFilter += " AND s.country_code = ANY(" + args.add(pqArray(ccodes)) + "::text[])"
+	}
+	// 候选集上限：search_name 先按重要性截断，再回表过滤
+	candidates := (p.Limit + p.Offset) * 20
+	if candidates < 200 {
+		candidates = 200
+	}
+
+	// 门牌：在命中的街道（rank_address 26/27）下查找 parent_place_id 指向该街道的门牌对象
+	houseUnion := ""
+	if housenumber != "" {
+		houseUnion = `
+
+    UNION ALL
+    SELECT h.place_id, m.exact, true AS is_house
+    FROM matches m
+    JOIN placex h ON h.parent_place_id = m.place_id
+    WHERE m.address_rank BETWEEN 26 AND 27
+      AND h.housenumber IS NOT NULL
+      AND ` + args.add(housenumber) + ` = ANY(string_to_array(lower(h.housenumber), ';'))`
+	}
+
+	var filters []string
 	// featuretype 过滤：支持 "class:type" 或单值（匹配 class 或 type）
 	if ft := strings.TrimSpace(p.FeatureType); ft != "" {
 		if i := strings.Index(ft, ":"); i >= 0 {
-			classVal := strings.TrimSpace(ft[:i])
-			typeVal := strings.TrimSpace(ft[i+1:])
-			base += " AND class = $" + strconv.Itoa(argIdx)
-			args = append(args, classVal)
-			argIdx++
-			base += " AND type = $" + strconv.Itoa(argIdx)
-			args = append(args, typeVal)
-			argIdx++
+			filters = append(filters, "p.class = "+args.add(strings.TrimSpace(ft[:i])))
+			filters = append(filters, "p.type = "+args.add(strings.TrimSpace(ft[i+1:])))
 		} else {
 			// 对齐 v1 API：country/state/city/settlement 映射 rank_address 范围
 			minRank, maxRank := mapFeatureTypeToRankRange(ft)
 			if minRank > 0 || maxRank < math.MaxInt32 {
-				base += " AND rank_address BETWEEN $" + strconv.Itoa(argIdx) + " AND $" + strconv.Itoa(argIdx+1)
-				args = append(args, minRank, maxRank)
-				argIdx += 2
+				filters = append(filters, "p.rank_address BETWEEN "+args.add(minRank)+" AND "+args.add(maxRank))
 			} else {
 				// 回退到按 class/type 单值匹配
-				base += " AND (class = $" + strconv.Itoa(argIdx) + " OR type = $" + strconv.Itoa(argIdx) + ")"
-				args = append(args, ft)
-				argIdx++
+				ph := args.add(ft)
+				filters = append(filters, "(p.class = "+ph+" OR p.type = "+ph+")")
 			}
 		}
 	}
 	// viewbox 容错：仅当 bounded=true 且 viewbox 非全零且 left<right、bottom<top 时应用
-	if p.Bounded {
-		left, right := p.ViewBoxLeft, p.ViewBoxRight
-		top, bottom := p.ViewBoxTop, p.ViewBoxBottom
-		if !(left == 0 && right == 0 && top == 0 && bottom == 0) && left < right && bottom < top {
-			base += " AND bbox && ST_MakeEnvelope($" + strconv.Itoa(argIdx) + ", $" + strconv.Itoa(argIdx+1) + ", $" + strconv.Itoa(argIdx+2) + ", $" + strconv.Itoa(argIdx+3) + ", 4326)"
-			args = append(args, left, bottom, right, top)
-			argIdx += 4
-		}
+	hasViewBox := (p.ViewBoxLeft != 0 || p.ViewBoxRight != 0 || p.ViewBoxTop != 0 || p.ViewBoxBottom != 0) && p.ViewBoxLeft < p.ViewBoxRight && p.ViewBoxBottom < p.ViewBoxTop
+	if p.Bounded && hasViewBox {
+		filters = append(filters, "p.geometry && ST_MakeEnvelope("+args.add(p.ViewBoxLeft)+", "+args.add(p.ViewBoxBottom)+", "+args.add(p.ViewBoxRight)+", "+args.add(p.ViewBoxTop)+", 4326)")
 	}
 	if len(p.Layers) > 0 {
 		classes := mapLayersToClasses(p.Layers)
@@ -149,159 +218,164 @@ FROM deduped
 			for c := range classes {
 				list = append(list, c)
 			}
-			base += " AND class = ANY($" + strconv.Itoa(argIdx) + ")"
-			args = append(args, pqArray(list))
-			argIdx++
+			filters = append(filters, "p.class = ANY("+args.add(pqArray(list))+")")
 		}
 	}
 	if len(p.ExcludePlaceIDs) > 0 {
-		base += " AND place_id <> ALL($" + strconv.Itoa(argIdx) + ")"
-		// PostgreSQL 数组：使用自定义 pqArrayInt64
-		baseArgs := make([]string, 0, len(p.ExcludePlaceIDs))
+		ids := make([]string, 0, len(p.ExcludePlaceIDs))
 		for _, id := range p.ExcludePlaceIDs {
-			baseArgs = append(baseArgs, strconv.FormatInt(id, 10))
+			ids = append(ids, strconv.FormatInt(id, 10))
+		}
+		filters = append(filters, "p.place_id <> ALL("+args.add(pqArray(ids))+")")
+	}
+	if len(postcodes) > 0 {
+		match := "upper(replace(p.postcode, ' ', '')) = ANY(" + args.add(pqArray(postcodes)) + "::text[])"
+		if strict {
+			filters = append(filters, match)
+		} else {
+			filters = append(filters, "(p.postcode IS NULL OR "+match+")")
 		}
-		args = append(args, pqArray(baseArgs))
-		argIdx++
+	}
+	where := "p.linked_place_id IS NULL"
+	if len(filters) > 0 {
+		where += " AND " + strings.Join(filters, " AND ")
+	}
+
+	// 去重：相同 class/type/名称且栅格化质心一致者仅保留重要性高者
+	deduped := "SELECT * FROM base"
+	if p.Dedupe {
+		deduped = `SELECT DISTINCT ON (class, type, name, gcentroid) *
+  FROM base
+  ORDER BY class, type, name, gcentroid, is_house DESC, exact DESC, importance DESC, place_id DESC`
 	}
 	// 若提供 viewbox，则按视窗中心距离进行次级排序
-	if (p.ViewBoxLeft != 0 || p.ViewBoxRight != 0 || p.ViewBoxTop != 0 || p.ViewBoxBottom != 0) && p.ViewBoxLeft < p.ViewBoxRight && p.ViewBoxBottom < p.ViewBoxTop {
-		centerLon := (p.ViewBoxLeft + p.ViewBoxRight) / 2
-		centerLat := (p.ViewBoxBottom + p.ViewBoxTop) / 2
-		base += " ORDER BY " + orderPrefix + "importance DESC NULLS LAST, (centroid <-> ST_SetSRID(ST_Point($" + strconv.Itoa(argIdx) + ",$" + strconv.Itoa(argIdx+1) + "), 4326)), place_id DESC"
-		args = append(args, centerLon, centerLat)
-		argIdx += 2
-	} else {
-		base += " ORDER BY " + orderPrefix + "importance DESC NULLS LAST, place_id DESC"
-	}
-	base += " LIMIT $" + strconv.Itoa(argIdx) + " OFFSET $" + strconv.Itoa(argIdx+1)
-	args = append(args, p.Limit, p.Offset)
-
-	rows, err := db.QueryContext(ctx, base, args...)
+	orderBy := "is_house DESC, exact DESC, importance DESC, place_id DESC"
+	if hasViewBox {
+		center := "ST_SetSRID(ST_Point(" + args.add((p.ViewBoxLeft+p.ViewBoxRight)/2) + ", " + args.add((p.ViewBoxBottom+p.ViewBoxTop)/2) + "), 4326)"
+		orderBy = "is_house DESC, exact DESC, importance DESC, (ST_SetSRID(ST_Point(lon, lat), 4326) <-> " + center + "), place_id DESC"
+	}
+
+	q := `
+WITH matches AS (
+  SELECT s.place_id, s.address_rank, (` + exactExpr + `) AS exact
+  FROM search_name s
+  WHERE ` + tokenFilter + `
+  ORDER BY exact DESC, s.importance DESC NULLS LAST
+  LIMIT ` + args.add(candidates) + `
+), candidates AS (
+  SELECT place_id, bool_or(exact) AS exact, bool_or(is_house) AS is_house
+  FROM (
+    SELECT m.place_id, m.exact, false AS is_house
+    FROM matches m` + houseUnion + `
+  ) u
+  GROUP BY place_id
+), base AS (
+  SELECT ` + placeColumns("p", geoJSONColumn("p", p.PolygonGeoJSON, p.PolygonThreshold)) + `,
+         ST_SnapToGrid(p.centroid, 0.0005) AS gcentroid,
+         c.exact, c.is_house
+  FROM candidates c
+  JOIN placex p ON p.place_id = c.place_id
+  WHERE ` + where + `
+), deduped AS (
+  ` + deduped + `
+)
+SELECT ` + placeColumnNames + `
+FROM deduped
+ORDER BY ` + orderBy + `
+LIMIT ` + args.add(p.Limit) + ` OFFSET ` + args.add(p.Offset)
+	out, err := r.queryPlaces(ctx, db, q, args.args...)
+	if err != nil || housenumber == "" {
+		return out, err
+	}
+	return r.interpolateHousenumbers(ctx, db, p, out, housenumber)
+}
+
+// queryPlaces 执行返回 placeColumns 的查询，读完结果后批量填充地址行。
+func (r *searchRepo) queryPlaces(ctx context.Context, db *sql.DB, q string, args ...any) ([]*biz.SearchPlace, error) {
+	rows, err := db.QueryContext(ctx, q, args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-
-	var out []*biz.SearchPlace
+	out := []*biz.SearchPlace{}
 	for rows.Next() {
-		var it biz.SearchPlace
-		var osmType string
-		var nameJSON, extratagsJSON, poly string
-		if err := rows.Scan(&it.PlaceID, &it.OSMID, &osmType, &it.Category, &it.Type, &it.Name, &it.Lat, &it.Lon, &it.Importance, &it.BBoxSouth, &it.BBoxNorth, &it.BBoxWest, &it.BBoxEast, &nameJSON, &extratagsJSON, &poly); err != nil {
+		it, err := scanPlace(rows)
+		if err != nil {
 			return nil, err
 		}
-		switch strings.ToUpper(osmType) {
-		case "N":
-			it.OSMType = "node"
-		case "W":
-			it.OSMType = "way"
-		case "R":
-			it.OSMType = "relation"
-		default:
-			it.OSMType = strings.ToLower(osmType)
-		}
-		_ = json.Unmarshal([]byte(nameJSON), &it.NameDetails)
-		_ = json.Unmarshal([]byte(extratagsJSON), &it.ExtraTags)
-		it.PolygonGeoJSON = poly
-		// addressdetails
-		if p.AddressDetails {
-			rows2, err2 := r.fetchAddressRows(ctx, db, it.PlaceID)
-			if err2 == nil {
-				it.AddressRows = rows2
-			}
-		}
-		out = append(out, &it)
+		out = append(out, it)
 	}
 	if err := rows.Err(); err != nil {
 		return nil, err
 	}
+	if err := r.attachAddressRows(ctx, db, out); err != nil {
+		return nil, err
+	}
 	return out, nil
 }
 
-func (r *searchRepo) ReversePlace(ctx context.Context, p biz.ReverseParams) (*biz.SearchPlace, error) {
-	if !(r.data.conf.Database.Driver == "postgres" || r.data.conf.Database.Driver == "postgresql" || r.data.conf.Database.Driver == "pgx") {
-		return nil, nil
-	}
-	db := r.sqlDB()
-	if db == nil {
-		return nil, nil
+// searchPostcodeBoundaries 匹配邮编边界对象（boundary=postal_code，postcodes 为去空白的候选邮编）。
+func (r *searchRepo) searchPostcodeBoundaries(ctx context.Context, db *sql.DB, p biz.SearchParams, postcodes []string) ([]*biz.SearchPlace, error) {
+	args := &sqlArgs{}
+	where := "p.linked_place_id IS NULL AND p.class = 'boundary' AND p.type = 'postal_code'" +
+		" AND upper(replace(COALESCE(p.postcode, p.name->'ref'), ' ', '')) = ANY(" + args.add(pqArray(postcodes)) + "::text[])"
+	if ccodes := splitCountryCodes(p.CountryCodes); len(ccodes) > 0 {
+		where += " AND p.country_code = ANY(" + args.add(pqArray(ccodes)) + "::text[])"
 	}
+	q := `
+SELECT ` + placeColumns("p", geoJSONColumn("p", p.PolygonGeoJSON, p.PolygonThreshold)) + `
+FROM placex p
+WHERE ` + where + `
+ORDER BY p.importance DESC NULLS LAST, p.place_id DESC
+LIMIT ` + args.add(p.Limit) + ` OFFSET ` + args.add(p.Offset)
+	return r.queryPlaces(ctx, db, q, args.args...)
+}
 
-	geoJSONSelect := "''"
-	if p.PolygonGeoJSON {
-		if p.PolygonThreshold > 0 {
-			geoJSONSelect = "COALESCE(ST_AsGeoJSON(ST_Simplify(polygon, " + strconv.FormatFloat(p.PolygonThreshold, 'f', -1, 64) + "), 6)::text, '')"
-		} else {
-			geoJSONSelect = "COALESCE(ST_AsGeoJSON(polygon, 6)::text, '')"
+// splitCountryCodes 解析逗号分隔的国家代码列表（小写）。
+func splitCountryCodes(s string) []string {
+	var out []string
+	for _, c := range strings.Split(s, ",") {
+		c = strings.TrimSpace(strings.ToLower(c))
+		if c != "" {
+			out = append(out, c)
 		}
 	}
+	return out
+}
 
-	// 将 zoom 转换为 rank 上限，近似：对齐 v1 的 helpers.zoom_to_rank
-	maxRank := zoomToMaxRank(p.Zoom)
-	q := `
-SELECT place_id, osm_id, osm_type, class, type,
-       COALESCE(name->'name','') AS name,
-       COALESCE(ST_Y(centroid), 0) AS lat,
-       COALESCE(ST_X(centroid), 0) AS lon,
-       COALESCE(importance, 0) AS importance,
-       COALESCE(ST_YMin(bbox), 0) AS south,
-       COALESCE(ST_YMax(bbox), 0) AS north,
-       COALESCE(ST_XMin(bbox), 0) AS west,
-       COALESCE(ST_XMax(bbox), 0) AS east,
-       COALESCE(hstore_to_json(name)::text, '{}') AS name_json,
-       COALESCE(hstore_to_json(extratags)::text, '{}') AS extratags_json,
-       ` + geoJSONSelect + ` AS polygon_geojson
-FROM placex
-WHERE rank_address <= $3`
+// normalizePostcode 邮编归一化：去空白并大写。
+func normalizePostcode(s string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(s), ""))
+}
 
-	args := []any{p.Lon, p.Lat, maxRank}
-	if len(p.Layers) > 0 {
-		classes := mapLayersToClasses(p.Layers)
-		if len(classes) > 0 {
-			list := make([]string, 0, len(classes))
-			for c := range classes {
-				list = append(list, c)
-			}
-			q += " AND class = ANY($4)"
-			args = append(args, pqArray(list))
-		}
+// ReversePlace 对齐 Nominatim 逆地理：街道/POI 按几何距离查找，未命中时回退到包含该点的最小地址面与国家。
+func (r *searchRepo) ReversePlace(ctx context.Context, p biz.ReverseParams) (*biz.SearchPlace, error) {
+	if !r.isPostgres() {
+		return nil, nil
+	}
+	db := r.sqlDB()
+	if db == nil {
+		return nil, nil
 	}
-	q += `
-ORDER BY centroid <-> ST_SetSRID(ST_Point($1,$2), 4326)
-LIMIT 1`
 
-	row := db.QueryRowContext(ctx, q, args...)
-	var it biz.SearchPlace
-	var osmType string
-	var nameJSON, extratagsJSON, poly string
-	if err := row.Scan(&it.PlaceID, &it.OSMID, &osmType, &it.Category, &it.Type, &it.Name, &it.Lat, &it.Lon, &it.Importance, &it.BBoxSouth, &it.BBoxNorth, &it.BBoxWest, &it.BBoxEast, &nameJSON, &extratagsJSON, &poly); err != nil {
+	q := &reverseQuery{
+		db:      db,
+		p:       p,
+		maxRank: zoomToMaxRank(p.Zoom),
+		layers:  parseReverseLayers(p.Layers),
+	}
+	it, err := q.lookup(ctx)
+	if err != nil || it == nil {
 		return nil, err
 	}
-	switch strings.ToUpper(osmType) {
-	case "N":
-		it.OSMType = "node"
-	case "W":
-		it.OSMType = "way"
-	case "R":
-		it.OSMType = "relation"
-	default:
-		it.OSMType = strings.ToLower(osmType)
-	}
-	_ = json.Unmarshal([]byte(nameJSON), &it.NameDetails)
-	_ = json.Unmarshal([]byte(extratagsJSON), &it.ExtraTags)
-	it.PolygonGeoJSON = poly
-	if p.AddressDetails {
-		rows2, err2 := r.fetchAddressRows(ctx, db, it.PlaceID)
-		if err2 == nil {
-			it.AddressRows = rows2
-		}
+	if err := r.attachAddressRows(ctx, db, []*biz.SearchPlace{it}); err != nil {
+		return nil, err
 	}
-	return &it, nil
+	return it, nil
 }
 
 func (r *searchRepo) LookupPlaces(ctx context.Context, p biz.LookupParams) ([]*biz.SearchPlace, error) {
-	if !(r.data.conf.Database.Driver == "postgres" || r.data.conf.Database.Driver == "postgresql" || r.data.conf.Database.Driver == "pgx") {
+	if !r.isPostgres() {
 		return []*biz.SearchPlace{}, nil
 	}
 	db := r.sqlDB()
@@ -343,92 +417,104 @@ func (r *searchRepo) LookupPlaces(ctx context.Context, p biz.LookupParams) ([]*b
 		return []*biz.SearchPlace{}, nil
 	}
 
-	geoJSONSelect := "COALESCE(ST_AsGeoJSON(polygon, 6)::text, '')"
-	if p.PolygonGeoJSON && p.PolygonThreshold > 0 {
-		geoJSONSelect = "COALESCE(ST_AsGeoJSON(ST_Simplify(polygon, " + strconv.FormatFloat(p.PolygonThreshold, 'f', -1, 64) + "), 6)::text, '')"
-	}
-
 	q := `
-SELECT place_id, osm_id, osm_type, class, type,
-       COALESCE(name->'name','') AS name,
-       COALESCE(ST_Y(centroid), 0) AS lat,
-       COALESCE(ST_X(centroid), 0) AS lon,
-       COALESCE(importance, 0) AS importance,
-       COALESCE(ST_YMin(bbox), 0) AS south,
-       COALESCE(ST_YMax(bbox), 0) AS north,
-       COALESCE(ST_XMin(bbox), 0) AS west,
-       COALESCE(ST_XMax(bbox), 0) AS east,
-       COALESCE(hstore_to_json(name)::text, '{}') AS name_json,
-       COALESCE(hstore_to_json(extratags)::text, '{}') AS extratags_json,
-       ` + geoJSONSelect + ` AS polygon_geojson
+SELECT ` + placeColumns("placex", geoJSONColumn("placex", p.PolygonGeoJSON, p.PolygonThreshold)) + `
 FROM placex
 WHERE ` + strings.Join(parts, " OR ") + `
 ORDER BY importance DESC NULLS LAST`
+	return r.queryPlaces(ctx, db, q, args...)
+}
 
-	rows, err := db.QueryContext(ctx, q, args...)
-	if err != nil {
-		return nil, err
+// addressLinesCTE 地址行来源（$1 为对象 place_id 数组）：对象自身的 place_addressline；
+// rank 30 对象、邮编（location_postcode）与门牌插值（location_property_osmline）再加上父对象及父对象的地址行。输出 lines(owner, address_place_id, cached_rank_address, isaddress, distance)，owner 为所属对象。
+const addressLinesCTE = `
+WITH target AS (
+  SELECT place_id, parent_place_id, rank_search FROM placex WHERE place_id = ANY($1::bigint[])
+  UNION ALL
+  SELECT place_id, parent_place_id, 30 FROM location_postcode WHERE place_id = ANY($1::bigint[])
+  UNION ALL
+  SELECT place_id, parent_place_id, 30 FROM location_property_osmline WHERE place_id = ANY($1::bigint[])
+), lines AS (
+  SELECT t.place_id AS owner, pa.address_place_id, pa.cached_rank_address, pa.isaddress, pa.distance
+  FROM target t JOIN place_addressline pa ON pa.place_id = t.place_id
+  UNION ALL
+  SELECT t.place_id, par.place_id, par.rank_address, true, 0
+  FROM target t JOIN placex par ON par.place_id = t.parent_place_id
+  WHERE t.rank_search >= 30
+  UNION ALL
+  SELECT t.place_id, pa.address_place_id, pa.cached_rank_address, pa.isaddress, pa.distance
+  FROM target t JOIN place_addressline pa ON pa.place_id = t.parent_place_id
+  WHERE t.rank_search >= 30
+)`
+
+// placeIDArray 将 place_id 列表转为 bigint[] 参数。
+func placeIDArray(ids []int64) any {
+	ss := make([]string, 0, len(ids))
+	for _, id := range ids {
+		ss = append(ss, strconv.FormatInt(id, 10))
 	}
-	defer rows.Close()
+	return pqArray(ss)
+}
 
-	var out []*biz.SearchPlace
-	for rows.Next() {
-		var it biz.SearchPlace
-		var osmType string
-		var nameJSON, extratagsJSON, poly string
-		if err := rows.Scan(&it.PlaceID, &it.OSMID, &osmType, &it.Category, &it.Type, &it.Name, &it.Lat, &it.Lon, &it.Importance, &it.BBoxSouth, &it.BBoxNorth, &it.BBoxWest, &it.BBoxEast, &nameJSON, &extratagsJSON, &poly); err != nil {
-			return nil, err
-		}
-		switch strings.ToUpper(osmType) {
-		case "N":
-			it.OSMType = "node"
-		case "W":
-			it.OSMType = "way"
-		case "R":
-			it.OSMType = "relation"
-		default:
-			it.OSMType = strings.ToLower(osmType)
-		}
-		_ = json.Unmarshal([]byte(nameJSON), &it.NameDetails)
-		_ = json.Unmarshal([]byte(extratagsJSON), &it.ExtraTags)
-		it.PolygonGeoJSON = poly
-		if p.AddressDetails {
-			rows2, err2 := r.fetchAddressRows(ctx, db, it.PlaceID)
-			if err2 == nil {
-				it.AddressRows = rows2
-			}
-		}
-		out = append(out, &it)
+// attachAddressRows 为结果批量填充地址行（展示名称总是需要，addressdetails 仅决定是否输出）。
+// 须在结果游标读完之后调用；读取失败时返回错误，避免缺少地址的结果被缓存。
+func (r *searchRepo) attachAddressRows(ctx context.Context, db *sql.DB, items []*biz.SearchPlace) error {
+	if len(items) == 0 {
+		return nil
 	}
-	if err := rows.Err(); err != nil {
-		return nil, err
+	ids := make([]int64, 0, len(items))
+	for _, it := range items {
+		ids = append(ids, it.PlaceID)
 	}
-	return out, nil
+	byOwner, err := r.fetchAddressRows(ctx, db, ids)
+	if err != nil {
+		return err
+	}
+	for _, it := range items {
+		it.AddressRows = byOwner[it.PlaceID]
+	}
+	return nil
 }
 
-// fetchAddressRows 读取地址行（按常见 nominatim 表 place_addressline）。
-func (r *searchRepo) fetchAddressRows(ctx context.Context, db *sql.DB, placeID int64) ([]biz.AddressRowItem, error) {
-	q := `
+// fetchAddressRows 一次读取多个对象的地址行，按所属对象分组：place_addressline 仅保存 address_place_id，名称等信息需回表 placex。
+// 对齐 Nominatim：rank 30 对象（POI/门牌）本身不建地址行，地址取自父对象（通常为街道）及其地址行。
+// 每组按地址等级由具体到宽泛排序；同等级时 isaddress 优先、距离近者优先。
+func (r *searchRepo) fetchAddressRows(ctx context.Context, db *sql.DB, placeIDs []int64) (map[int64][]biz.AddressRowItem, error) {
+	q := addressLinesCTE + `
 SELECT
-  COALESCE(addresstype, '') AS component,
-  COALESCE(address, '') AS name,
-  COALESCE(admin_level, 0) AS admin_level,
-  COALESCE(cached_rank_address, 0) AS rank
-FROM place_addressline
-WHERE place_id = $1
-ORDER BY cached_rank_address ASC`
-	rows, err := db.QueryContext(ctx, q, placeID)
+  l.owner,
+  a.place_id,
+  a.class,
+  a.type,
+  COALESCE(a.name->'name', '') AS name,
+  COALESCE(hstore_to_json(a.name)::text, '{}') AS name_json,
+  COALESCE(hstore_to_json(a.extratags)::text, '{}') AS extratags_json,
+  COALESCE(a.admin_level, 0) AS admin_level,
+  COALESCE(a.country_code, '') AS country_code,
+  COALESCE(l.cached_rank_address, 0) AS rank,
+  COALESCE(l.isaddress, false) AS isaddress
+FROM lines l
+JOIN placex a ON a.place_id = l.address_place_id
+WHERE l.cached_rank_address > 0
+ORDER BY l.owner, l.cached_rank_address DESC, l.isaddress DESC, l.distance ASC`
+	rows, err := db.QueryContext(ctx, q, placeIDArray(placeIDs))
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var out []biz.AddressRowItem
+	out := make(map[int64][]biz.AddressRowItem, len(placeIDs))
 	for rows.Next() {
-		var item biz.AddressRowItem
-		if err := rows.Scan(&item.Component, &item.Name, &item.AdminLevel, &item.Rank); err != nil {
+		var (
+			owner                   int64
+			item                    biz.AddressRowItem
+			nameJSON, extratagsJSON string
+		)
+		if err := rows.Scan(&owner, &item.PlaceID, &item.Category, &item.Type, &item.Name, &nameJSON, &extratagsJSON, &item.AdminLevel, &item.CountryCode, &item.Rank, &item.IsAddress); err != nil {
 			return nil, err
 		}
-		out = append(out, item)
+		_ = json.Unmarshal([]byte(nameJSON), &item.Names)
+		_ = json.Unmarshal([]byte(extratagsJSON), &item.ExtraTags)
+		out[owner] = append(out[owner], item)
 	}
 	if err := rows.Err(); err != nil {
 		return nil, err
@@ -440,8 +526,8 @@ func pqArray(ss []string) any { return "{" + strings.Join(ss, ",") + "}" }
 
 // zoomToMaxRank 将 zoom 映射到 rank 上限，基于 0..18 的离散表。
 func zoomToMaxRank(zoom int) int {
-	// 近似对齐：低 zoom 聚合到更上层行政等级，高 zoom 允许更细粒度
-	table := []int{2, 3, 4, 6, 8, 10, 12, 14, 15, 16, 18, 20, 22, 24, 26, 27, 28, 29, 30}
+	// 对齐 Nominatim REVERSE_MAX_RANKS：大洲/国家/州/地区/县/城市/镇/村/小村/地点/主干道/次干道/建筑
+	table := []int{2, 2, 2, 4, 4, 8, 10, 10, 12, 12, 16, 17, 18, 19, 22, 25, 26, 27, 30}
 	if zoom < 0 {
 		zoom = 0
 	}
@@ -0,0 +1,222 @@
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"strings"
+
+	"nominatim-go/internal/biz"
+	"nominatim-go/internal/conf"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/go-kratos/kratos/v2/transport/http"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// authGroup 一个受保护的路由组。
+type authGroup struct {
+	name       string
+	operations []string
+	tokens     [][]byte
+	identities map[string]struct{}
+}
+
+// matches operation 是否属于本组（精确匹配，或以 * 结尾的前缀匹配）。
+func (g *authGroup) matches(op string) bool {
+	for _, pattern := range g.operations {
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			if strings.HasPrefix(op, prefix) {
+				return true
+			}
+		} else if op == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+// allowToken 常量时间比较 token。
+func (g *authGroup) allowToken(token string) bool {
+	for _, t := range g.tokens {
+		if subtle.ConstantTimeCompare(t, []byte(token)) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// Authenticator 按路由组鉴权：静态 bearer token/API key 或 mTLS 客户端身份，HTTP 与 gRPC 共用。
+type Authenticator struct {
+	apiKeyHeader string
+	groups       []*authGroup
+}
+
+// NewAuthenticator 依据 conf.Server.Auth 构造鉴权器；未配置路由组时返回 nil（不鉴权）。
+func NewAuthenticator(c *conf.Server) *Authenticator {
+	ac := c.GetAuth()
+	if len(ac.GetGroups()) == 0 {
+		return nil
+	}
+	a := &Authenticator{apiKeyHeader: ac.GetApiKeyHeader()}
+	if a.apiKeyHeader == "" {
+		a.apiKeyHeader = "X-API-Key"
+	}
+	for _, gc := range ac.GetGroups() {
+		g := &authGroup{name: gc.GetName(), operations: gc.GetOperations(), identities: map[string]struct{}{}}
+		for _, t := range gc.GetTokens() {
+			if t = strings.TrimSpace(t); t != "" {
+				g.tokens = append(g.tokens, []byte(t))
+			}
+		}
+		for _, id := range gc.GetClientIdentities() {
+			if id = strings.TrimSpace(id); id != "" {
+				g.identities[id] = struct{}{}
+			}
+		}
+		a.groups = append(a.groups, g)
+	}
+	return a
+}
+
+// group 返回 operation 所属的第一个路由组；未命中返回 nil。
+func (a *Authenticator) group(op string) *authGroup {
+	for _, g := range a.groups {
+		if g.matches(op) {
+			return g
+		}
+	}
+	return nil
+}
+
+// token 提取请求中的 token：Authorization: Bearer 优先，其次 API key 请求头。
+func (a *Authenticator) token(tr transport.Transporter) string {
+	if v := tr.RequestHeader().Get("Authorization"); v != "" {
+		if t, ok := cutPrefixFold(v, "Bearer "); ok {
+			return strings.TrimSpace(t)
+		}
+	}
+	return strings.TrimSpace(tr.RequestHeader().Get(a.apiKeyHeader))
+}
+
+// identity 返回经校验的客户端身份（供限流分桶）：被任一路由组接受的 token（以组名与序号标识，不保存 token 本身），
+// 或 TLS 握手已校验的客户端证书身份；均无（含未配置鉴权）时返回空。
+func (a *Authenticator) identity(ctx context.Context, tr transport.Transporter) string {
+	if a == nil {
+		return ""
+	}
+	if token := a.token(tr); token != "" {
+		for _, g := range a.groups {
+			for i, t := range g.tokens {
+				if subtle.ConstantTimeCompare(t, []byte(token)) == 1 {
+					return fmt.Sprintf("token:%s/%d", g.name, i)
+				}
+			}
+		}
+	}
+	if ids := clientIdentities(ctx); len(ids) > 0 {
+		return "mtls:" + ids[0]
+	}
+	return ""
+}
+
+// Middleware 鉴权中间件：未携带凭据返回 401，凭据不被该路由组接受返回 403。
+func (a *Authenticator) Middleware() middleware.Middleware {
+	return func(next middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (reply interface{}, err error) {
+			tr, ok := transport.FromServerContext(ctx)
+			if !ok {
+				return next(ctx, req)
+			}
+			g := a.group(tr.Operation())
+			if g == nil {
+				return next(ctx, req)
+			}
+			token := a.token(tr)
+			ids := clientIdentities(ctx)
+			if token == "" && len(ids) == 0 {
+				tr.ReplyHeader().Set("WWW-Authenticate", `Bearer realm="nominatim"`)
+				return nil, errors.Unauthorized(biz.Unauthorized, "authentication required")
+			}
+			if token != "" && g.allowToken(token) {
+				return next(ctx, req)
+			}
+			for _, id := range ids {
+				if _, ok := g.identities[id]; ok {
+					return next(ctx, req)
+				}
+			}
+			return nil, errors.Forbidden(biz.Forbidden, fmt.Sprintf("access to %s is not allowed", g.name))
+		}
+	}
+}
+
+// clientIdentities 提取已校验的客户端证书身份（Subject CN 与 SAN）；HTTP 取 r.TLS，gRPC 取 peer 的 TLSInfo。
+func clientIdentities(ctx context.Context) []string {
+	var chains [][]*x509.Certificate
+	if r, ok := http.RequestFromServerContext(ctx); ok {
+		if r.TLS != nil {
+			chains = r.TLS.VerifiedChains
+		}
+	} else if p, ok := peer.FromContext(ctx); ok {
+		if info, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+			chains = info.State.VerifiedChains
+		}
+	}
+	if len(chains) == 0 || len(chains[0]) == 0 {
+		return nil
+	}
+	cert := chains[0][0]
+	var ids []string
+	if cert.Subject.CommonName != "" {
+		ids = append(ids, cert.Subject.CommonName)
+	}
+	ids = append(ids, cert.DNSNames...)
+	ids = append(ids, cert.EmailAddresses...)
+	for _, u := range cert.URIs {
+		ids = append(ids, u.String())
+	}
+	return ids
+}
+
+func cutPrefixFold(s, prefix string) (string, bool) {
+	if len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix) {
+		return s[len(prefix):], true
+	}
+	return s, false
+}
+
+// NewServerTLSConfig 依据 conf.Server.TLS 构造 HTTP/gRPC 共用的 TLS 配置；未配置证书时返回 nil（明文）。
+func NewServerTLSConfig(c *conf.Server) (*tls.Config, error) {
+	tc := c.GetTls()
+	if tc.GetCertFile() == "" || tc.GetKeyFile() == "" {
+		return nil, nil
+	}
+	cert, err := tls.LoadX509KeyPair(tc.GetCertFile(), tc.GetKeyFile())
+	if err != nil {
+		return nil, err
+	}
+	cfg := &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12}
+	if tc.GetClientCaFile() != "" {
+		pem, err := os.ReadFile(tc.GetClientCaFile())
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", tc.GetClientCaFile())
+		}
+		cfg.ClientCAs = pool
+		cfg.ClientAuth = tls.VerifyClientCertIfGiven
+		if tc.GetRequireClientCert() {
+			cfg.ClientAuth = tls.RequireAndVerifyClientCert
+		}
+	}
+	return cfg, nil
+}
@@ -0,0 +1,113 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+
+	"nominatim-go/internal/conf"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/transport"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// mtlsContext 携带已校验客户端证书（CN 与 DNS SAN）的 gRPC peer。
+func mtlsContext(cn string, dns ...string) context.Context {
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: cn}, DNSNames: dns}
+	info := credentials.TLSInfo{State: tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}}
+	return peer.NewContext(context.Background(), &peer.Peer{AuthInfo: info})
+}
+
+func TestAuthenticator(t *testing.T) {
+	const (
+		details = "/nominatim.v1.NominatimService/Details"
+		search  = "/nominatim.v1.NominatimService/Search"
+		status  = "/nominatim.v1.NominatimService/Status"
+	)
+	auth := NewAuthenticator(&conf.Server{Auth: &conf.Server_Auth{Groups: []*conf.Server_Auth_Group{
+		{Name: "ops", Operations: []string{details}, Tokens: []string{"ops-secret"}, ClientIdentities: []string{"ops.internal"}},
+		{Name: "api", Operations: []string{"/nominatim.v1.NominatimService/S*"}, Tokens: []string{"api-secret"}},
+	}}})
+	tests := []struct {
+		name string
+		ctx  context.Context
+		op   string
+		kv   []string
+		code int // 期望的错误码（0 表示放行）
+	}{
+		{name: "missing credential", op: details, code: 401},
+		{name: "wrong token", op: details, kv: []string{"Authorization", "Bearer nope"}, code: 403},
+		{name: "token of another group", op: details, kv: []string{"Authorization", "Bearer api-secret"}, code: 403},
+		{name: "exact match bearer", op: details, kv: []string{"Authorization", "bearer ops-secret"}},
+		{name: "exact match api key", op: details, kv: []string{"X-API-Key", "ops-secret"}},
+		{name: "prefix match", op: search, kv: []string{"X-API-Key", "api-secret"}},
+		{name: "prefix match missing credential", op: search, code: 401},
+		{name: "unlisted operation passes", op: "/nominatim.v1.NominatimService/Lookup"},
+		{name: "mtls identity", ctx: mtlsContext("someone", "ops.internal"), op: details},
+		{name: "mtls identity not allowed", ctx: mtlsContext("someone"), op: details, code: 403},
+		{name: "mtls identity prefix group", ctx: mtlsContext("ops.internal"), op: status, code: 403},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := tt.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			tr := newTestTransport(tt.kv...)
+			tr.op = tt.op
+			called := false
+			h := auth.Middleware()(func(context.Context, any) (any, error) {
+				called = true
+				return "ok", nil
+			})
+			_, err := h(transport.NewServerContext(ctx, tr), nil)
+			if tt.code == 0 {
+				if err != nil || !called {
+					t.Fatalf("err = %v, called = %v; want pass", err, called)
+				}
+				return
+			}
+			if err == nil || int(errors.FromError(err).GetCode()) != tt.code {
+				t.Fatalf("err = %v, want code %d", err, tt.code)
+			}
+			if called {
+				t.Fatal("handler called for rejected request")
+			}
+			if got := tr.reply.Get("WWW-Authenticate"); (tt.code == 401) != (got != "") {
+				t.Fatalf("WWW-Authenticate = %q for code %d", got, tt.code)
+			}
+		})
+	}
+}
+
+func TestAuthenticatorIdentity(t *testing.T) {
+	auth := NewAuthenticator(&conf.Server{Auth: &conf.Server_Auth{Groups: []*conf.Server_Auth_Group{
+		{Name: "ops", Operations: []string{"*"}, Tokens: []string{"a", "b"}},
+	}}})
+	tests := []struct {
+		name string
+		ctx  context.Context
+		kv   []string
+		want string
+	}{
+		{name: "token", kv: []string{"Authorization", "Bearer b"}, want: "token:ops/1"},
+		{name: "unknown token", kv: []string{"Authorization", "Bearer c"}},
+		{name: "mtls common name first", ctx: mtlsContext("client-1", "client.example"), want: "mtls:client-1"},
+		{name: "none"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := tt.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			if got := auth.identity(ctx, newTestTransport(tt.kv...)); got != tt.want {
+				t.Fatalf("identity = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
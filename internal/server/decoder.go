@@ -0,0 +1,242 @@
+package server
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+
+	v1 "nominatim-go/api/nominatim/v1"
+	"nominatim-go/internal/biz"
+
+	"github.com/go-kratos/kratos/v2/transport/http"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// nominatimQueryPackage 使用 Nominatim 参数语法解析查询参数的 proto 包。
+const nominatimQueryPackage = "nominatim.v1"
+
+// 查询参数的取值范围（与 ResponseEncoder 支持的格式、data 层识别的图层一致）
+var (
+	queryFormats = []string{"json", "jsonv2", "geojson", "geocodejson", "xml", "html", "text"}
+	queryLayers  = []string{"address", "poi", "railway", "natural", "manmade", "man_made", "postcode", "transport", "boundary", "boundaries"}
+)
+
+// queryFieldIndex 按消息缓存的字段索引：归一化键名 -> 字段。
+var queryFieldIndex sync.Map
+
+// decodeNominatimQuery 按 Nominatim v1 参数语法解析 NominatimService 路由的查询参数：
+// 键名不区分连字符/下划线/大小写（accept-language、featureType、exclude_place_ids、osmid），
+// 列表以逗号分隔（osm_ids=N1,W2），布尔值为 0/1（亦接受 true/false），viewbox 为 "x1,y1,x2,y2"。
+// 未知参数忽略（与 Nominatim 一致），取值不合法时返回 400；其他服务沿用 kratos 默认解析。
+func decodeNominatimQuery(r *http.Request, v any) error {
+	m, ok := v.(proto.Message)
+	if !ok || m.ProtoReflect().Descriptor().ParentFile().Package() != nominatimQueryPackage {
+		return http.DefaultRequestQuery(r, v)
+	}
+	msg := m.ProtoReflect()
+	fields := queryFields(msg.Descriptor())
+	for key, values := range r.URL.Query() {
+		value := strings.TrimSpace(values[len(values)-1])
+		if key == "format" && value != "" && !oneOf(value, queryFormats) {
+			return queryError("Parameter 'format' must be one of: %s.", strings.Join(queryFormats, ", "))
+		}
+		if key == "json_callback" && value != "" && !validJSONCallback(value) {
+			return queryError("Invalid json_callback value.")
+		}
+		fd, ok := fields[normalizeQueryKey(key)]
+		if !ok {
+			continue
+		}
+		if err := setQueryField(msg, fd, key, values); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// normalizeQueryKey 键名归一化：小写并去掉 - 与 _。
+func normalizeQueryKey(key string) string {
+	return strings.NewReplacer("-", "", "_", "").Replace(strings.ToLower(key))
+}
+
+func queryFields(md protoreflect.MessageDescriptor) map[string]protoreflect.FieldDescriptor {
+	if v, ok := queryFieldIndex.Load(md.FullName()); ok {
+		return v.(map[string]protoreflect.FieldDescriptor)
+	}
+	fields := md.Fields()
+	idx := make(map[string]protoreflect.FieldDescriptor, fields.Len())
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		idx[normalizeQueryKey(string(fd.Name()))] = fd
+	}
+	queryFieldIndex.Store(md.FullName(), idx)
+	return idx
+}
+
+func setQueryField(msg protoreflect.Message, fd protoreflect.FieldDescriptor, key string, values []string) error {
+	switch {
+	case fd.IsMap():
+		return nil
+	case fd.IsList():
+		list := msg.Mutable(fd).List()
+		for _, raw := range values {
+			for _, s := range splitList(raw) {
+				val, err := parseQueryScalar(fd, key, s)
+				if err != nil {
+					return err
+				}
+				list.Append(val)
+			}
+		}
+		return nil
+	}
+	value := strings.TrimSpace(values[len(values)-1])
+	if value == "" {
+		return nil
+	}
+	if fd.Kind() == protoreflect.MessageKind {
+		switch fd.Message().FullName() {
+		case "nominatim.v1.ViewBox":
+			vb, err := parseViewBox(key, value)
+			if err != nil {
+				return err
+			}
+			msg.Set(fd, protoreflect.ValueOfMessage(vb.ProtoReflect()))
+		case "nominatim.v1.Locales":
+			msg.Set(fd, protoreflect.ValueOfMessage((&v1.Locales{Codes: splitList(value)}).ProtoReflect()))
+		}
+		return nil
+	}
+	if fd.Name() == "layer" {
+		for _, l := range splitList(value) {
+			if !oneOf(strings.ToLower(l), queryLayers) {
+				return queryError("Parameter '%s' must be a comma-separated list of: %s.", key, strings.Join(queryLayers, ", "))
+			}
+		}
+	}
+	val, err := parseQueryScalar(fd, key, value)
+	if err != nil {
+		return err
+	}
+	msg.Set(fd, val)
+	return nil
+}
+
+// parseQueryScalar 按字段类型解析单个值。
+func parseQueryScalar(fd protoreflect.FieldDescriptor, key, s string) (protoreflect.Value, error) {
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(s), nil
+	case protoreflect.BoolKind:
+		switch strings.ToLower(s) {
+		case "1", "true":
+			return protoreflect.ValueOfBool(true), nil
+		case "0", "false":
+			return protoreflect.ValueOfBool(false), nil
+		}
+		return protoreflect.Value{}, queryError("Parameter '%s' must be 0 or 1.", key)
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		n, err := strconv.ParseInt(s, 10, 32)
+		if err != nil {
+			return protoreflect.Value{}, queryError("Parameter '%s' must be a number.", key)
+		}
+		return protoreflect.ValueOfInt32(int32(n)), nil
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return protoreflect.Value{}, queryError("Parameter '%s' must be a number.", key)
+		}
+		return protoreflect.ValueOfInt64(n), nil
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		n, err := strconv.ParseUint(s, 10, 32)
+		if err != nil {
+			return protoreflect.Value{}, queryError("Parameter '%s' must be a non-negative number.", key)
+		}
+		return protoreflect.ValueOfUint32(uint32(n)), nil
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		n, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			return protoreflect.Value{}, queryError("Parameter '%s' must be a non-negative number.", key)
+		}
+		return protoreflect.ValueOfUint64(n), nil
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		f, err := parseQueryFloat(s)
+		if err != nil {
+			return protoreflect.Value{}, queryError("Parameter '%s' must be a number.", key)
+		}
+		if fd.Kind() == protoreflect.FloatKind {
+			return protoreflect.ValueOfFloat32(float32(f)), nil
+		}
+		return protoreflect.ValueOfFloat64(f), nil
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByName(protoreflect.Name(strings.ToUpper(s))); ev != nil {
+			return protoreflect.ValueOfEnum(ev.Number()), nil
+		}
+		return protoreflect.Value{}, queryError("Parameter '%s' has an unknown value '%s'.", key, s)
+	}
+	return protoreflect.Value{}, queryError("Parameter '%s' is not supported.", key)
+}
+
+// parseQueryFloat 解析有限浮点数。
+func parseQueryFloat(s string) (float64, error) {
+	f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil {
+		return 0, err
+	}
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, strconv.ErrSyntax
+	}
+	return f, nil
+}
+
+// parseViewBox 解析 viewbox=x1,y1,x2,y2（任意两个对角，对齐 Nominatim 的归一化）。
+func parseViewBox(key, s string) (*v1.ViewBox, error) {
+	parts := strings.Split(s, ",")
+	if len(parts) != 4 {
+		return nil, queryError("Bad parameter '%s'. Expected 4 coordinates.", key)
+	}
+	var c [4]float64
+	for i, p := range parts {
+		f, err := parseQueryFloat(p)
+		if err != nil {
+			return nil, queryError("Bad parameter '%s'. Expected 4 coordinates.", key)
+		}
+		c[i] = f
+	}
+	vb := &v1.ViewBox{
+		Left:   math.Min(c[0], c[2]),
+		Right:  math.Max(c[0], c[2]),
+		Bottom: math.Min(c[1], c[3]),
+		Top:    math.Max(c[1], c[3]),
+	}
+	if vb.Left == vb.Right || vb.Bottom == vb.Top {
+		return nil, queryError("Bad parameter '%s'. Not a box with width and height.", key)
+	}
+	return vb, nil
+}
+
+// splitList 拆分逗号分隔的列表（去空白、去空项）。
+func splitList(s string) []string {
+	var out []string
+	for _, p := range strings.Split(s, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+func oneOf(s string, list []string) bool {
+	for _, v := range list {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
+func queryError(format string, args ...any) error {
+	return biz.ErrBadParameter(format, args...)
+}
@@ -0,0 +1,130 @@
+package server
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	v1 "nominatim-go/api/nominatim/v1"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestDecodeNominatimQuery(t *testing.T) {
+	linked := false
+	tests := []struct {
+		name  string
+		query string
+		msg   proto.Message // 解码目标（空消息）
+		want  proto.Message // 期望结果；nil 表示应返回 400
+	}{
+		{
+			name:  "search keys and lists",
+			query: "q=Berlin&accept-language=de&featureType=city&exclude_place_ids=1,2&exclude_place_ids=3&addressdetails=1&limit=5&countrycodes=de,at",
+			msg:   &v1.SearchRequest{},
+			want: &v1.SearchRequest{
+				Q: "Berlin", AcceptLanguage: "de", Featuretype: "city", ExcludePlaceIds: []int64{1, 2, 3},
+				Addressdetails: true, Limit: 5, Countrycodes: "de,at",
+			},
+		},
+		{
+			name:  "bool words",
+			query: "dedupe=false&bounded=TRUE",
+			msg:   &v1.SearchRequest{},
+			want:  &v1.SearchRequest{Bounded: true},
+		},
+		{
+			name:  "viewbox any corners",
+			query: "viewbox=13.5,52.6,13.3,52.4",
+			msg:   &v1.SearchRequest{},
+			want:  &v1.SearchRequest{Viewbox: &v1.ViewBox{Left: 13.3, Right: 13.5, Bottom: 52.4, Top: 52.6}},
+		},
+		{
+			name:  "locales list",
+			query: "locales=de,+en,",
+			msg:   &v1.SearchRequest{},
+			want:  &v1.SearchRequest{Locales: &v1.Locales{Codes: []string{"de", "en"}}},
+		},
+		{
+			name:  "layer list",
+			query: "layer=address,POI",
+			msg:   &v1.SearchRequest{},
+			want:  &v1.SearchRequest{Layer: "address,POI"},
+		},
+		{
+			name:  "unknown ignored and last value wins",
+			query: "foo=bar&q=a&q=b&format=jsonv2",
+			msg:   &v1.SearchRequest{},
+			want:  &v1.SearchRequest{Q: "b"},
+		},
+		{
+			name:  "empty value keeps default",
+			query: "limit=&q=x",
+			msg:   &v1.SearchRequest{},
+			want:  &v1.SearchRequest{Q: "x"},
+		},
+		{
+			name:  "lookup osm_ids",
+			query: "osm_ids=N1,W2,,R3",
+			msg:   &v1.LookupRequest{},
+			want:  &v1.LookupRequest{OsmIds: []string{"N1", "W2", "R3"}},
+		},
+		{
+			name:  "details osmid and optional bool",
+			query: "osmid=123&osmtype=W&linkedplaces=0",
+			msg:   &v1.DetailsRequest{},
+			want:  &v1.DetailsRequest{OsmId: "123", Osmtype: "W", Linkedplaces: &linked},
+		},
+		{
+			name:  "reverse coordinates",
+			query: "lat=52.5&lon=13.4&zoom=18",
+			msg:   &v1.ReverseRequest{},
+			want:  &v1.ReverseRequest{Lat: 52.5, Lon: 13.4, Zoom: 18},
+		},
+		{name: "bad bool", query: "addressdetails=yes", msg: &v1.SearchRequest{}},
+		{name: "negative uint", query: "limit=-1", msg: &v1.SearchRequest{}},
+		{name: "bad int list item", query: "exclude_place_ids=1,x", msg: &v1.SearchRequest{}},
+		{name: "viewbox three coordinates", query: "viewbox=1,2,3", msg: &v1.SearchRequest{}},
+		{name: "viewbox without width", query: "viewbox=1,2,1,3", msg: &v1.SearchRequest{}},
+		{name: "viewbox not finite", query: "viewbox=1,2,Inf,3", msg: &v1.SearchRequest{}},
+		{name: "unknown layer", query: "layer=address,foo", msg: &v1.SearchRequest{}},
+		{name: "unknown format", query: "format=pdf", msg: &v1.SearchRequest{}},
+		{name: "bad json_callback", query: "json_callback=alert(1)", msg: &v1.SearchRequest{}},
+		{name: "bad float", query: "lat=NaN", msg: &v1.ReverseRequest{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/search?"+tt.query, nil)
+			err := decodeNominatimQuery(r, tt.msg)
+			if tt.want == nil {
+				if errors.Code(err) != 400 {
+					t.Fatalf("err = %v, want 400", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !proto.Equal(tt.msg, tt.want) {
+				t.Fatalf("decoded = %v, want %v", tt.msg, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeQueryKey(t *testing.T) {
+	tests := []struct {
+		key, want string
+	}{
+		{"accept-language", "acceptlanguage"},
+		{"accept_language", "acceptlanguage"},
+		{"featureType", "featuretype"},
+		{"exclude_place_ids", "excludeplaceids"},
+		{"OSM_ID", "osmid"},
+	}
+	for _, tt := range tests {
+		if got := normalizeQueryKey(tt.key); got != tt.want {
+			t.Errorf("normalizeQueryKey(%q) = %q, want %q", tt.key, got, tt.want)
+		}
+	}
+}
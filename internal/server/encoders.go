@@ -1,14 +1,22 @@
 package server
 
 import (
+	"bytes"
 	"encoding/json"
 	"encoding/xml"
 	"fmt"
+	"html"
 	"math"
 	"net/url"
 	v1 "nominatim-go/api/nominatim/v1"
+	"nominatim-go/internal/biz"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"unicode"
 
+	"github.com/go-kratos/kratos/v2/errors"
 	"github.com/go-kratos/kratos/v2/transport/http"
 )
 
@@ -64,6 +72,9 @@ func encodeGeoJSON(w http.ResponseWriter, r *http.Request, v any) error {
 			"display_name": p.GetDisplayName(),
 			"importance":   p.GetImportance(),
 		}
+		if addr := p.GetAddress(); len(addr) > 0 {
+			props["address"] = addr
+		}
 		var bbox []float64
 		if b := p.GetBoundingbox(); b != nil {
 			bbox = []float64{b.GetWest(), b.GetSouth(), b.GetEast(), b.GetNorth()}
@@ -94,16 +105,8 @@ func encodeGeoJSON(w http.ResponseWriter, r *http.Request, v any) error {
 		fc.Features = append(fc.Features, geoJSONFeature{Type: "Feature", Properties: props, BBox: bbox, Geometry: geom})
 	}
 	if cb := r.URL.Query().Get("json_callback"); cb != "" {
-		w.Header().Set("Content-Type", "application/javascript; charset=utf-8")
 		b, _ := json.Marshal(fc)
-		if _, err := w.Write([]byte(cb + "(")); err != nil {
-			return err
-		}
-		if _, err := w.Write(b); err != nil {
-			return err
-		}
-		_, err := w.Write([]byte(")"))
-		return err
+		return writeJSONP(w, cb, b)
 	}
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	enc := json.NewEncoder(w)
@@ -171,21 +174,31 @@ func encodeGeocodeJSON(w http.ResponseWriter, r *http.Request, v any) error {
 		})
 	}
 	if cb := r.URL.Query().Get("json_callback"); cb != "" {
-		w.Header().Set("Content-Type", "application/javascript; charset=utf-8")
 		b, _ := json.Marshal(out)
-		if _, err := w.Write([]byte(cb + "(")); err != nil {
-			return err
-		}
-		if _, err := w.Write(b); err != nil {
-			return err
-		}
-		_, err := w.Write([]byte(")"))
-		return err
+		return writeJSONP(w, cb, b)
 	}
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	return json.NewEncoder(w).Encode(out)
 }
 
+// jsonCallbackPattern 合法的 JSONP 回调名（与 Nominatim 一致：标识符，可含 . 与 []），防止脚本注入。
+var jsonCallbackPattern = regexp.MustCompile(`^[$_\p{L}][$_\p{L}\p{Nl}\p{Nd}.\[\]]*$`)
+
+// validJSONCallback 回调名是否合法。
+func validJSONCallback(cb string) bool {
+	return jsonCallbackPattern.MatchString(cb)
+}
+
+// writeJSONP 以 cb(...) 包裹 JSON 输出；回调名不合法时返回 400（不写出任何内容）。
+func writeJSONP(w http.ResponseWriter, cb string, b []byte) error {
+	if !validJSONCallback(cb) {
+		return biz.ErrBadParameter("Invalid json_callback value.")
+	}
+	w.Header().Set("Content-Type", "application/javascript; charset=utf-8")
+	_, err := w.Write([]byte(cb + "(" + string(b) + ")"))
+	return err
+}
+
 // Minimal XML output (compact)
 type xmlSearchResults struct {
 	XMLName         xml.Name   `xml:"searchresults"`
@@ -194,21 +207,113 @@ type xmlSearchResults struct {
 	Place           []xmlPlace `xml:"place"`
 }
 type xmlReverse struct {
-	XMLName xml.Name `xml:"reversegeocode"`
-	Result  xmlPlace `xml:"result"`
+	XMLName      xml.Name         `xml:"reversegeocode"`
+	Result       xmlPlace         `xml:"result"`
+	AddressParts *xmlAddressParts `xml:"addressparts,omitempty"`
+}
+type xmlAddressParts struct {
+	Parts xmlAddress `xml:"parts"`
 }
 type xmlPlace struct {
-	XMLName     xml.Name `xml:"result"`
-	PlaceID     int64    `xml:"place_id,attr"`
-	OsmType     string   `xml:"osm_type,attr"`
-	OsmID       string   `xml:"osm_id,attr"`
-	DisplayName string   `xml:"display_name,attr"`
-	Class       string   `xml:"class,attr"`
-	Type        string   `xml:"type,attr"`
-	Importance  float64  `xml:"importance,attr"`
-	Lat         float64  `xml:"lat,attr"`
-	Lon         float64  `xml:"lon,attr"`
-	BoundingBox string   `xml:"boundingbox,attr,omitempty"`
+	XMLName     xml.Name   `xml:"result"`
+	PlaceID     int64      `xml:"place_id,attr"`
+	OsmType     string     `xml:"osm_type,attr"`
+	OsmID       string     `xml:"osm_id,attr"`
+	DisplayName string     `xml:"display_name,attr"`
+	Class       string     `xml:"class,attr"`
+	Type        string     `xml:"type,attr"`
+	Importance  float64    `xml:"importance,attr"`
+	Lat         float64    `xml:"lat,attr"`
+	Lon         float64    `xml:"lon,attr"`
+	BoundingBox string     `xml:"boundingbox,attr,omitempty"`
+	Address     xmlAddress `xml:"address,omitempty"`
+}
+
+// xmlAddress 以地址键为元素名输出（<road>..</road><city>..</city>），不带外层元素。
+type xmlAddress []addressKV
+
+func (a xmlAddress) MarshalXML(e *xml.Encoder, _ xml.StartElement) error {
+	for _, kv := range a {
+		if err := e.EncodeElement(kv.Value, xml.StartElement{Name: xml.Name{Local: xmlElementName(kv.Key)}}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// xmlElementName 将地址键转为合法的 XML 元素名（NCName，不含冒号）：
+// 非法字符替换为 _，首字符须为字母或 _，以 xml 开头（保留前缀）时同样加 _ 前缀。
+func xmlElementName(key string) string {
+	var b strings.Builder
+	for i, r := range key {
+		switch {
+		case r == '_' || unicode.IsLetter(r):
+		case i > 0 && (r == '-' || r == '.' || unicode.IsDigit(r) || unicode.Is(unicode.Mn, r)):
+		default:
+			if i == 0 && (r == '-' || r == '.' || unicode.IsDigit(r)) {
+				b.WriteByte('_')
+				break
+			}
+			r = '_'
+		}
+		b.WriteRune(r)
+	}
+	name := b.String()
+	if name == "" || strings.HasPrefix(strings.ToLower(name), "xml") {
+		name = "_" + name
+	}
+	return name
+}
+
+// addressKV 地址对象的一个键值，用于需保序的输出格式。
+type addressKV struct {
+	Key   string
+	Value string
+}
+
+// addressKeyOrder 地址键由具体到宽泛的顺序（proto map 无序，输出时按此恢复 Nominatim 的键顺序）。
+var addressKeyOrder = func() map[string]int {
+	keys := []string{
+		"house_number", "house_name", "road",
+		"hamlet", "croft", "isolated_dwelling", "city_block",
+		"residential", "farm", "farmyard", "industrial", "commercial", "retail",
+		"neighbourhood", "allotments", "quarter", "suburb", "borough",
+		"city_district", "district", "subdivision",
+		"village", "town", "city", "municipality",
+		"county", "ISO3166-2-lvl6", "state_district", "ISO3166-2-lvl5",
+		"state", "ISO3166-2-lvl4", "region", "ISO3166-2-lvl3",
+		"postcode", "country", "country_code",
+	}
+	m := make(map[string]int, len(keys))
+	for i, k := range keys {
+		m[k] = i + 1
+	}
+	return m
+}()
+
+// orderedAddress 将地址对象按由具体到宽泛排序；未知键（多为 POI 自身的类别，如 amenity/shop）排在最前。
+func orderedAddress(addr map[string]string) []addressKV {
+	out := make([]addressKV, 0, len(addr))
+	for k, v := range addr {
+		out = append(out, addressKV{Key: k, Value: v})
+	}
+	rank := func(k string) int {
+		if r, ok := addressKeyOrder[k]; ok {
+			return r
+		}
+		if strings.HasPrefix(k, "ISO3166-2-lvl") {
+			return addressKeyOrder["postcode"]
+		}
+		return 0
+	}
+	sort.Slice(out, func(i, j int) bool {
+		ri, rj := rank(out[i].Key), rank(out[j].Key)
+		if ri != rj {
+			return ri < rj
+		}
+		return out[i].Key < out[j].Key
+	})
+	return out
 }
 
 func toXMLPlace(p *v1.Place) xmlPlace {
@@ -231,6 +336,7 @@ func toXMLPlace(p *v1.Place) xmlPlace {
 		Lat:         lat,
 		Lon:         lon,
 		BoundingBox: bbox,
+		Address:     orderedAddress(p.GetAddress()),
 	}
 }
 
@@ -299,10 +405,297 @@ func encodeXML(w http.ResponseWriter, r *http.Request, v any) error {
 		if t.GetResult() == nil {
 			return enc.Encode(xmlReverse{})
 		}
-		return enc.Encode(xmlReverse{Result: toXMLPlace(t.GetResult())})
+		xr := xmlReverse{Result: toXMLPlace(t.GetResult())}
+		// 逆地理的地址放在 <addressparts> 中，而非 <result> 内
+		if addr := xr.Result.Address; len(addr) > 0 {
+			xr.AddressParts = &xmlAddressParts{Parts: addr}
+			xr.Result.Address = nil
+		}
+		return enc.Encode(xr)
+	default:
+		return http.DefaultResponseEncoder(w, r, v)
+	}
+}
+
+// --- Nominatim json/jsonv2 ---
+
+// jsonField 有序 JSON 对象的一个字段。
+type jsonField struct {
+	Key   string
+	Value any
+}
+
+// orderedJSON 保持字段顺序的 JSON 对象（对齐 Nominatim 输出的字段顺序）。
+type orderedJSON []jsonField
+
+func (o orderedJSON) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, f := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, err := json.Marshal(f.Key)
+		if err != nil {
+			return nil, err
+		}
+		v, err := json.Marshal(f.Value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// queryFlag 解析布尔查询参数（1/true）。
+func queryFlag(q url.Values, key string) bool {
+	v := strings.ToLower(strings.TrimSpace(q.Get(key)))
+	return v == "1" || v == "true"
+}
+
+// toNominatimJSON 按 Nominatim format_base_json 的字段顺序输出单个结果；
+// classLabel 为 "class"（json）或 "category"（jsonv2）。
+func toNominatimJSON(p *v1.Place, q url.Values, classLabel string) orderedJSON {
+	c := p.GetCentroid()
+	out := orderedJSON{
+		{"place_id", p.GetPlaceId()},
+		{"licence", p.GetLicence()},
+	}
+	if p.GetOsmType() != "" {
+		var osmID any = p.GetOsmId()
+		if id, err := strconv.ParseInt(p.GetOsmId(), 10, 64); err == nil {
+			osmID = id
+		}
+		out = append(out, jsonField{"osm_type", p.GetOsmType()}, jsonField{"osm_id", osmID})
+	}
+	out = append(out,
+		jsonField{"lat", strconv.FormatFloat(c.GetLat(), 'f', -1, 64)},
+		jsonField{"lon", strconv.FormatFloat(c.GetLon(), 'f', -1, 64)},
+		jsonField{classLabel, p.GetCategory()},
+		jsonField{"type", p.GetType()},
+		jsonField{"place_rank", p.GetPlaceRank()},
+		jsonField{"importance", p.GetImportance()},
+		jsonField{"addresstype", p.GetAddresstype()},
+		jsonField{"name", p.GetName()},
+		jsonField{"display_name", p.GetDisplayName()},
+	)
+	if queryFlag(q, "addressdetails") {
+		addr := orderedJSON{}
+		for _, kv := range orderedAddress(p.GetAddress()) {
+			addr = append(addr, jsonField{kv.Key, kv.Value})
+		}
+		out = append(out, jsonField{"address", addr})
+	}
+	if queryFlag(q, "extratags") {
+		out = append(out, jsonField{"extratags", p.GetExtratags()})
+	}
+	if queryFlag(q, "namedetails") {
+		out = append(out, jsonField{"namedetails", p.GetNamedetails()})
+	}
+	b := p.GetBoundingbox()
+	out = append(out, jsonField{"boundingbox", []string{
+		fmt.Sprintf("%0.7f", b.GetSouth()),
+		fmt.Sprintf("%0.7f", b.GetNorth()),
+		fmt.Sprintf("%0.7f", b.GetWest()),
+		fmt.Sprintf("%0.7f", b.GetEast()),
+	}})
+	if gj := p.GetPolygonGeojson(); gj != "" {
+		if queryFlag(q, "polygon_geojson") && json.Valid([]byte(gj)) {
+			out = append(out, jsonField{"geojson", json.RawMessage(gj)})
+		}
+		if coords := extractPolygonCoordinatesFromGeoJSON(gj); len(coords) > 0 {
+			if queryFlag(q, "polygon_svg") {
+				out = append(out, jsonField{"svg", toPolygonSVG(coords)})
+			}
+			if queryFlag(q, "polygon_text") {
+				out = append(out, jsonField{"geotext", toPolygonText(coords)})
+			}
+			if queryFlag(q, "polygon_kml") {
+				out = append(out, jsonField{"geokml", toPolygonKML(coords)})
+			}
+		}
+	}
+	return out
+}
+
+// toNominatimDetailsJSON 按 Nominatim 详情页（/details?format=json）的字段顺序输出。
+func toNominatimDetailsJSON(d *v1.DetailsResponse) orderedJSON {
+	p := d.GetResult()
+	var osmID any = p.GetOsmId()
+	if id, err := strconv.ParseInt(p.GetOsmId(), 10, 64); err == nil {
+		osmID = id
+	}
+	osmType := ""
+	if p.GetOsmType() != "" {
+		osmType = strings.ToUpper(p.GetOsmType()[:1])
+	}
+	c := p.GetCentroid()
+	centroid := orderedJSON{{"type", "Point"}, {"coordinates", []float64{c.GetLon(), c.GetLat()}}}
+	var geometry any = centroid
+	if gj := p.GetPolygonGeojson(); gj != "" {
+		geometry = json.RawMessage(gj)
+	}
+	out := orderedJSON{
+		{"place_id", p.GetPlaceId()},
+		{"parent_place_id", d.GetParentPlaceId()},
+		{"linked_place_id", d.GetLinkedPlaceId()},
+		{"osm_type", osmType},
+		{"osm_id", osmID},
+		{"category", p.GetCategory()},
+		{"type", p.GetType()},
+		{"admin_level", d.GetAdminLevel()},
+		{"localname", d.GetLocalname()},
+		{"names", d.GetNames()},
+		{"addresstags", d.GetAddresstags()},
+		{"housenumber", nullIfEmpty(d.GetHousenumber())},
+		{"calculated_postcode", nullIfEmpty(d.GetCalculatedPostcode())},
+		{"country_code", nullIfEmpty(d.GetCountryCode())},
+		{"indexed_date", d.GetIndexedDate()},
+		{"importance", p.GetImportance()},
+		{"calculated_importance", d.GetCalculatedImportance()},
+		{"extratags", p.GetExtratags()},
+		{"calculated_wikipedia", nullIfEmpty(d.GetCalculatedWikipedia())},
+		{"rank_address", d.GetRankAddress()},
+		{"rank_search", d.GetRankSearch()},
+		{"isarea", d.GetIsarea()},
+		{"centroid", centroid},
+		{"geometry", geometry},
+	}
+	if len(d.GetAddress()) > 0 {
+		out = append(out, jsonField{"address", detailsLinesJSON(d.GetAddress())})
+	}
+	if len(d.GetLinkedPlaces()) > 0 {
+		out = append(out, jsonField{"linked_places", detailsLinesJSON(d.GetLinkedPlaces())})
+	}
+	if kw := d.GetKeywords(); kw != nil {
+		out = append(out, jsonField{"keywords", orderedJSON{
+			{"name", keywordsJSON(kw.GetName())},
+			{"address", keywordsJSON(kw.GetAddress())},
+		}})
+	}
+	return out
+}
+
+func detailsLinesJSON(lines []*v1.DetailsAddressLine) []orderedJSON {
+	out := make([]orderedJSON, 0, len(lines))
+	for _, l := range lines {
+		out = append(out, orderedJSON{
+			{"localname", l.GetLocalname()},
+			{"place_id", l.GetPlaceId()},
+			{"osm_id", l.GetOsmId()},
+			{"osm_type", l.GetOsmType()},
+			{"place_type", nullIfEmpty(l.GetPlaceType())},
+			{"class", l.GetClass()},
+			{"type", l.GetType()},
+			{"admin_level", l.GetAdminLevel()},
+			{"rank_address", l.GetRankAddress()},
+			{"distance", l.GetDistance()},
+			{"isaddress", l.GetIsaddress()},
+		})
+	}
+	return out
+}
+
+func keywordsJSON(kws []*v1.DetailsKeyword) []orderedJSON {
+	out := make([]orderedJSON, 0, len(kws))
+	for _, k := range kws {
+		out = append(out, orderedJSON{{"id", k.GetId()}, {"token", k.GetToken()}})
+	}
+	return out
+}
+
+// nullIfEmpty 空串输出为 JSON null（与 Nominatim 一致）。
+func nullIfEmpty(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// encodeNominatimJSON 输出 Nominatim 兼容的 json/jsonv2：search/lookup 为数组，reverse/details 为单个对象。
+func encodeNominatimJSON(w http.ResponseWriter, r *http.Request, v any, classLabel string) error {
+	q := r.URL.Query()
+	var body any
+	switch t := v.(type) {
+	case *v1.SearchResponse:
+		list := make([]orderedJSON, 0, len(t.GetResults()))
+		for _, p := range t.GetResults() {
+			if p != nil {
+				list = append(list, toNominatimJSON(p, q, classLabel))
+			}
+		}
+		body = list
+	case *v1.LookupResponse:
+		list := make([]orderedJSON, 0, len(t.GetResults()))
+		for _, p := range t.GetResults() {
+			if p != nil {
+				list = append(list, toNominatimJSON(p, q, classLabel))
+			}
+		}
+		body = list
+	case *v1.ReverseResponse:
+		if t.GetResult() == nil {
+			body = map[string]string{"error": "Unable to geocode"}
+		} else {
+			body = toNominatimJSON(t.GetResult(), q, classLabel)
+		}
+	case *v1.DetailsResponse:
+		if t.GetResult() == nil {
+			body = map[string]string{"error": "No place with that OSM ID found."}
+		} else {
+			body = toNominatimDetailsJSON(t)
+		}
+	case *v1.DeletableResponse:
+		list := make([]orderedJSON, 0, len(t.GetObjects()))
+		for _, o := range t.GetObjects() {
+			list = append(list, orderedJSON{
+				{"place_id", o.GetPlaceId()},
+				{"country_code", o.GetCountryCode()},
+				{"name", o.GetName()},
+				{"osm_id", o.GetOsmId()},
+				{"osm_type", o.GetOsmType()},
+				{"class", o.GetClass()},
+				{"type", o.GetType()},
+			})
+		}
+		body = list
+	case *v1.PolygonsResponse:
+		list := make([]orderedJSON, 0, len(t.GetPolygons()))
+		for _, e := range t.GetPolygons() {
+			var loc any
+			if l := e.GetErrorLocation(); l != nil {
+				loc = orderedJSON{{"lat", l.GetLat()}, {"lon", l.GetLon()}}
+			}
+			list = append(list, orderedJSON{
+				{"osm_type", e.GetOsmType()},
+				{"osm_id", e.GetOsmId()},
+				{"class", e.GetClass()},
+				{"type", e.GetType()},
+				{"name", nullIfEmpty(e.GetName())},
+				{"country_code", nullIfEmpty(e.GetCountryCode())},
+				{"errormessage", e.GetErrormessage()},
+				{"error_location", loc},
+				{"updated", e.GetUpdated()},
+			})
+		}
+		body = list
 	default:
 		return http.DefaultResponseEncoder(w, r, v)
 	}
+	b, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	if cb := q.Get("json_callback"); cb != "" {
+		return writeJSONP(w, cb, b)
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	_, err = w.Write(b)
+	return err
 }
 
 // --- polygon helpers ---
@@ -431,3 +824,141 @@ func toPolygonKML(polys [][][2]float64) string {
 	sb.WriteString("</coordinates></LinearRing></outerBoundaryIs></Polygon>")
 	return sb.String()
 }
+
+// --- maintenance (html/text) ---
+
+// maintenanceTable 将维护端点的响应转换为表格；非维护响应返回 false。
+func maintenanceTable(v any) (title string, header []string, rows [][]string, ok bool) {
+	switch t := v.(type) {
+	case *v1.DeletableResponse:
+		header = []string{"place_id", "country_code", "name", "osm_type", "osm_id", "class", "type"}
+		for _, o := range t.GetObjects() {
+			rows = append(rows, []string{
+				strconv.FormatInt(o.GetPlaceId(), 10), o.GetCountryCode(), o.GetName(),
+				o.GetOsmType(), strconv.FormatInt(o.GetOsmId(), 10), o.GetClass(), o.GetType(),
+			})
+		}
+		return "Deletable objects", header, rows, true
+	case *v1.PolygonsResponse:
+		header = []string{"osm_type", "osm_id", "class", "type", "name", "country_code", "errormessage", "error_location", "updated"}
+		for _, e := range t.GetPolygons() {
+			loc := ""
+			if l := e.GetErrorLocation(); l != nil {
+				loc = strconv.FormatFloat(l.GetLat(), 'f', 7, 64) + "," + strconv.FormatFloat(l.GetLon(), 'f', 7, 64)
+			}
+			rows = append(rows, []string{
+				e.GetOsmType(), strconv.FormatInt(e.GetOsmId(), 10), e.GetClass(), e.GetType(), e.GetName(),
+				e.GetCountryCode(), e.GetErrormessage(), loc, e.GetUpdated(),
+			})
+		}
+		return "Broken polygons", header, rows, true
+	default:
+		return "", nil, nil, false
+	}
+}
+
+// encodeHTML 以 HTML 表格输出维护端点结果（对齐 Nominatim 早期维护页面）。
+func encodeHTML(w http.ResponseWriter, r *http.Request, v any) error {
+	title, header, rows, ok := maintenanceTable(v)
+	if !ok {
+		return http.DefaultResponseEncoder(w, r, v)
+	}
+	var b strings.Builder
+	b.WriteString("<!DOCTYPE html>\n<html><head><meta charset=\"utf-8\"><title>")
+	b.WriteString(html.EscapeString(title))
+	b.WriteString("</title></head><body>\n<h1>")
+	b.WriteString(html.EscapeString(title))
+	fmt.Fprintf(&b, "</h1>\n<p>%d objects</p>\n<table>\n<tr>", len(rows))
+	for _, h := range header {
+		b.WriteString("<th>" + html.EscapeString(h) + "</th>")
+	}
+	b.WriteString("</tr>\n")
+	for _, row := range rows {
+		b.WriteString("<tr>")
+		for _, c := range row {
+			b.WriteString("<td>" + html.EscapeString(c) + "</td>")
+		}
+		b.WriteString("</tr>\n")
+	}
+	b.WriteString("</table>\n</body></html>\n")
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, err := w.Write([]byte(b.String()))
+	return err
+}
+
+// encodeText 以制表符分隔的文本输出维护端点结果（首行为表头）。
+func encodeText(w http.ResponseWriter, r *http.Request, v any) error {
+	_, header, rows, ok := maintenanceTable(v)
+	if !ok {
+		return http.DefaultResponseEncoder(w, r, v)
+	}
+	clean := strings.NewReplacer("\t", " ", "\n", " ", "\r", " ")
+	var b strings.Builder
+	b.WriteString(strings.Join(header, "\t") + "\n")
+	for _, row := range rows {
+		for i, c := range row {
+			if i > 0 {
+				b.WriteByte('\t')
+			}
+			b.WriteString(clean.Replace(c))
+		}
+		b.WriteByte('\n')
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	_, err := w.Write([]byte(b.String()))
+	return err
+}
+
+// encodeNominatimError 按请求的 format 输出 Nominatim 风格的错误（对齐 Nominatim v1 format_error）：
+// xml 为 <error><code/><message/></error>，html/text 为简单页面/纯文本，其余为 {"error":{"code":..,"message":..}}。
+// /reverse 无结果（biz.ErrNotFound）时与 Nominatim 一致返回 200：json 类为 {"error":"Unable to geocode"}，xml 为 <reversegeocode><error/></reversegeocode>。
+func encodeNominatimError(w http.ResponseWriter, r *http.Request, err error) {
+	se := errors.FromError(err)
+	code := int(se.Code)
+	format := r.URL.Query().Get("format")
+	if errors.IsNotFound(err) && strings.HasSuffix(r.URL.Path, "/reverse") && format != "html" && format != "text" {
+		encodeReverseMiss(w, format, se.Message)
+		return
+	}
+	switch format {
+	case "xml":
+		var b bytes.Buffer
+		b.WriteString(xml.Header)
+		b.WriteString("<error><code>" + strconv.Itoa(code) + "</code><message>")
+		_ = xml.EscapeText(&b, []byte(se.Message))
+		b.WriteString("</message></error>\n")
+		w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+		w.WriteHeader(code)
+		_, _ = w.Write(b.Bytes())
+	case "html":
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(code)
+		_, _ = fmt.Fprintf(w, "<html><head><title>Error</title></head><body><h1>Error %d</h1><p>%s</p></body></html>\n", code, html.EscapeString(se.Message))
+	case "text":
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.WriteHeader(code)
+		_, _ = fmt.Fprintf(w, "ERROR %d: %s\n", code, se.Message)
+	default:
+		body, _ := json.Marshal(orderedJSON{{"error", orderedJSON{{"code", code}, {"message", se.Message}}}})
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(code)
+		_, _ = w.Write(body)
+	}
+}
+
+// encodeReverseMiss 输出 Nominatim 的逆地理无结果响应。
+func encodeReverseMiss(w http.ResponseWriter, format, message string) {
+	if format == "xml" {
+		var b bytes.Buffer
+		b.WriteString(xml.Header)
+		b.WriteString("<reversegeocode><error>")
+		_ = xml.EscapeText(&b, []byte(message))
+		b.WriteString("</error></reversegeocode>\n")
+		w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+		_, _ = w.Write(b.Bytes())
+		return
+	}
+	body, _ := json.Marshal(orderedJSON{{"error", message}})
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	_, _ = w.Write(body)
+}
@@ -0,0 +1,108 @@
+package server
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/errors"
+)
+
+func TestValidJSONCallback(t *testing.T) {
+	tests := []struct {
+		cb   string
+		want bool
+	}{
+		{"cb", true},
+		{"$jsonp_1", true},
+		{"window.handlers[0]", true},
+		{"回调", true},
+		{"1cb", false},
+		{"alert(document.cookie)//", false},
+		{"cb;alert(1)", false},
+		{"cb</script>", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := validJSONCallback(tt.cb); got != tt.want {
+			t.Errorf("validJSONCallback(%q) = %v, want %v", tt.cb, got, tt.want)
+		}
+	}
+}
+
+func TestWriteJSONP(t *testing.T) {
+	w := httptest.NewRecorder()
+	if err := writeJSONP(w, "cb", []byte(`{"a":1}`)); err != nil {
+		t.Fatal(err)
+	}
+	if got := w.Body.String(); got != `cb({"a":1})` {
+		t.Errorf("body = %q", got)
+	}
+	w = httptest.NewRecorder()
+	err := writeJSONP(w, "alert(1)//", []byte(`{}`))
+	if !errors.IsBadRequest(err) {
+		t.Fatalf("err = %v, want 400", err)
+	}
+	if w.Body.Len() != 0 {
+		t.Errorf("body written for invalid callback: %q", w.Body.String())
+	}
+}
+
+func TestXMLElementName(t *testing.T) {
+	tests := []struct {
+		key, want string
+	}{
+		{"road", "road"},
+		{"ISO3166-2-lvl4", "ISO3166-2-lvl4"},
+		{"house_number", "house_number"},
+		{"addr:street", "addr_street"},
+		{"city district", "city_district"},
+		{"1st", "_1st"},
+		{"-x", "_-x"},
+		{"a<b>&c", "a_b__c"},
+		{"xmlfoo", "_xmlfoo"},
+		{"街道", "街道"},
+		{"", "_"},
+	}
+	for _, tt := range tests {
+		if got := xmlElementName(tt.key); got != tt.want {
+			t.Errorf("xmlElementName(%q) = %q, want %q", tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestXMLAddressWellFormed(t *testing.T) {
+	p := xmlPlace{Address: xmlAddress{
+		{Key: "road", Value: "Pariser Platz"},
+		{Key: "addr:city", Value: "Berlin"},
+		{Key: "1st level", Value: "x"},
+		{Key: "a b", Value: "<&>"},
+	}}
+	b, err := xml.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := xml.NewDecoder(strings.NewReader(string(b)))
+	var names []string
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("invalid XML %s: %v", b, err)
+		}
+		if se, ok := tok.(xml.StartElement); ok {
+			if se.Name.Space != "" {
+				t.Fatalf("element %v has a namespace prefix in %s", se.Name, b)
+			}
+			names = append(names, se.Name.Local)
+		}
+	}
+	want := []string{"result", "road", "addr_city", "_1st_level", "a_b"}
+	if strings.Join(names, ",") != strings.Join(want, ",") {
+		t.Fatalf("elements = %v, want %v", names, want)
+	}
+}
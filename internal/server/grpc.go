@@ -1,22 +1,36 @@
 package server
 
 import (
+	"crypto/tls"
+
 	hv1 "nominatim-go/api/helloworld/v1"
 	v1 "nominatim-go/api/nominatim/v1"
 	"nominatim-go/internal/conf"
 	"nominatim-go/internal/service"
 
 	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware"
 	"github.com/go-kratos/kratos/v2/middleware/recovery"
 	"github.com/go-kratos/kratos/v2/transport/grpc"
 )
 
 // NewGRPCServer new a gRPC server.
-func NewGRPCServer(c *conf.Server, greeter *service.GreeterService, nominatim *service.NominatimService, logger log.Logger) *grpc.Server {
+func NewGRPCServer(c *conf.Server, limiter *RateLimiter, auth *Authenticator, validator *Validator, tlsConf *tls.Config, greeter *service.GreeterService, nominatim *service.NominatimService, logger log.Logger) *grpc.Server {
+	mws := []middleware.Middleware{
+		recovery.Recovery(),
+	}
+	// 与 HTTP 共用限流器
+	if limiter != nil {
+		mws = append(mws, limiter.Middleware())
+	}
+	// 与 HTTP 共用鉴权配置
+	if auth != nil {
+		mws = append(mws, auth.Middleware())
+	}
+	// 按 buf.validate 规则校验请求（鉴权之后，避免向未授权方暴露参数规则）
+	mws = append(mws, validator.Middleware())
 	var opts = []grpc.ServerOption{
-		grpc.Middleware(
-			recovery.Recovery(),
-		),
+		grpc.Middleware(mws...),
 	}
 	if c.Grpc.Network != "" {
 		opts = append(opts, grpc.Network(c.Grpc.Network))
@@ -27,6 +41,9 @@ func NewGRPCServer(c *conf.Server, greeter *service.GreeterService, nominatim *s
 	if c.Grpc.Timeout != nil {
 		opts = append(opts, grpc.Timeout(c.Grpc.Timeout.AsDuration()))
 	}
+	if tlsConf != nil {
+		opts = append(opts, grpc.TLSConfig(tlsConf))
+	}
 	srv := grpc.NewServer(opts...)
 	hv1.RegisterGreeterServer(srv, greeter)
 	v1.RegisterNominatimServiceServer(srv, nominatim)
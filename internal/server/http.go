@@ -1,12 +1,12 @@
 package server
 
 import (
+	"crypto/tls"
+
 	hv1 "nominatim-go/api/helloworld/v1"
 	v1 "nominatim-go/api/nominatim/v1"
 	"nominatim-go/internal/conf"
 	"nominatim-go/internal/service"
-	"os"
-	"strconv"
 
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/go-kratos/kratos/v2/middleware"
@@ -19,20 +19,23 @@ import (
 // 编码相关逻辑已拆分到 encoders.go
 
 // NewHTTPServer new an HTTP server.
-func NewHTTPServer(c *conf.Server, greeter *service.GreeterService, nominatim *service.NominatimService, logger log.Logger) *http.Server {
+func NewHTTPServer(c *conf.Server, limiter *RateLimiter, auth *Authenticator, validator *Validator, tlsConf *tls.Config, greeter *service.GreeterService, nominatim *service.NominatimService, logger log.Logger) *http.Server {
 	var opts = []http.ServerOption{}
 	// 基础中间件
 	baseMw := []middleware.Middleware{
 		recovery.Recovery(),
 		logging.Server(logger),
 	}
-	// 简单令牌桶限流（基于环境变量 NOMINATIM_RPS）
-	if rpsStr := os.Getenv("NOMINATIM_RPS"); rpsStr != "" {
-		if rps, err := strconv.Atoi(rpsStr); err == nil && rps > 0 {
-			limiter := newTokenBucket(float64(rps))
-			baseMw = append(baseMw, limiterMiddleware(limiter))
-		}
+	// 按客户端分桶限流（与 gRPC 共用同一限流器）
+	if limiter != nil {
+		baseMw = append(baseMw, limiter.Middleware())
+	}
+	// 按路由组鉴权（token/API key/mTLS）
+	if auth != nil {
+		baseMw = append(baseMw, auth.Middleware())
 	}
+	// 按 buf.validate 规则校验请求（鉴权之后，避免向未授权方暴露参数规则）
+	baseMw = append(baseMw, validator.Middleware())
 	opts = append(opts,
 		http.Middleware(baseMw...),
 		http.ResponseEncoder(func(w http.ResponseWriter, r *http.Request, v any) error {
@@ -44,11 +47,29 @@ func NewHTTPServer(c *conf.Server, greeter *service.GreeterService, nominatim *s
 					return encodeGeocodeJSON(w, r, v)
 				} else if q == "xml" {
 					return encodeXML(w, r, v)
+				} else if q == "jsonv2" {
+					return encodeNominatimJSON(w, r, v, "category")
+				} else if q == "json" {
+					return encodeNominatimJSON(w, r, v, "class")
+				} else if q == "html" {
+					return encodeHTML(w, r, v)
+				} else if q == "text" {
+					return encodeText(w, r, v)
 				}
 			}
 			return http.DefaultResponseEncoder(w, r, v)
 		}),
 		http.RequestDecoder(http.DefaultRequestDecoder),
+		// 查询参数按 Nominatim v1 语法解析（见 decoder.go）
+		http.RequestQueryDecoder(decodeNominatimQuery),
+		// 指定 format 的请求（Nominatim 客户端）以 Nominatim 风格输出错误
+		http.ErrorEncoder(func(w http.ResponseWriter, r *http.Request, err error) {
+			if r != nil && r.URL.Query().Get("format") != "" {
+				encodeNominatimError(w, r, err)
+				return
+			}
+			http.DefaultErrorEncoder(w, r, err)
+		}),
 	)
 	if c.Http.Network != "" {
 		opts = append(opts, http.Network(c.Http.Network))
@@ -59,10 +80,15 @@ func NewHTTPServer(c *conf.Server, greeter *service.GreeterService, nominatim *s
 	if c.Http.Timeout != nil {
 		opts = append(opts, http.Timeout(c.Http.Timeout.AsDuration()))
 	}
+	if tlsConf != nil {
+		opts = append(opts, http.TLSConfig(tlsConf))
+	}
 	srv := http.NewServer(opts...)
 	hv1.RegisterGreeterHTTPServer(srv, greeter)
 	v1.RegisterNominatimServiceHTTPServer(srv, nominatim)
 	// Prometheus /metrics
 	srv.Handle("/metrics", promhttp.Handler())
+	// OpenAPI 文档与离线浏览页
+	registerOpenAPI(srv)
 	return srv
 }
@@ -0,0 +1,30 @@
+package server
+
+import (
+	_ "embed"
+
+	"github.com/go-kratos/kratos/v2/transport/http"
+)
+
+// openapi.json 由 cmd/protoc-gen-nominatim-openapi 从 nominatim.proto 生成（make openapi），随二进制内嵌。
+//
+//go:embed openapi/openapi.json
+var openapiSpec []byte
+
+// 离线 API 浏览页（无 CDN 依赖）
+//
+//go:embed openapi/index.html
+var openapiPage []byte
+
+// registerOpenAPI 注册 /openapi.json 与 /docs；与 /metrics 一样不经过 kratos 中间件（无需鉴权）。
+func registerOpenAPI(srv *http.Server) {
+	srv.HandleFunc("/openapi.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		_, _ = w.Write(openapiSpec)
+	})
+	srv.HandleFunc("/docs", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write(openapiPage)
+	})
+}
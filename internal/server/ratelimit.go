@@ -2,11 +2,22 @@ package server
 
 import (
 	"context"
+	"fmt"
+	"math"
+	"net"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
+	v1 "nominatim-go/api/nominatim/v1"
+	"nominatim-go/internal/conf"
+
 	"github.com/go-kratos/kratos/v2/errors"
 	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/go-kratos/kratos/v2/transport/http"
+	"google.golang.org/grpc/peer"
 )
 
 // 基础令牌桶
@@ -18,32 +29,47 @@ type tokenBucket struct {
 	mu         sync.Mutex
 }
 
-func newTokenBucket(rps float64) *tokenBucket {
+func newTokenBucket(rps float64, burst int) *tokenBucket {
 	if rps <= 0 {
 		rps = 1
 	}
+	capacity := float64(burst)
+	if capacity < 1 {
+		capacity = rps * 2
+	}
 	b := &tokenBucket{
 		rate:       rps,
-		capacity:   rps * 2,
-		tokens:     rps * 2,
+		capacity:   capacity,
+		tokens:     capacity,
 		lastRefill: time.Now(),
 	}
 	return b
 }
 
-func (b *tokenBucket) allow() bool {
+// take 尝试取 n 个令牌，返回是否放行、剩余令牌数，以及被拒绝时需等待的时长（n 超过容量时永远无法放行，wait 为 0）。
+func (b *tokenBucket) take(now time.Time, n int) (ok bool, remaining int, wait time.Duration) {
 	b.mu.Lock()
 	defer b.mu.Unlock()
-	now := time.Now()
 	delta := now.Sub(b.lastRefill).Seconds()
 	b.tokens = minFloat(b.capacity, b.tokens+delta*b.rate)
-	if b.tokens >= 1 {
-		b.tokens -= 1
-		b.lastRefill = now
-		return true
-	}
 	b.lastRefill = now
-	return false
+	cost := float64(n)
+	if b.tokens >= cost {
+		b.tokens -= cost
+		return true, int(b.tokens), 0
+	}
+	if cost > b.capacity {
+		return false, int(b.tokens), 0
+	}
+	wait = time.Duration((cost - b.tokens) / b.rate * float64(time.Second))
+	return false, int(b.tokens), wait
+}
+
+// resetAfter 令牌桶回满所需时长。
+func (b *tokenBucket) resetAfter() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return time.Duration((b.capacity - b.tokens) / b.rate * float64(time.Second))
 }
 
 func minFloat(a, b float64) float64 {
@@ -53,14 +79,203 @@ func minFloat(a, b float64) float64 {
 	return b
 }
 
-// limiterMiddleware 将限流应用到 HTTP 请求
-func limiterMiddleware(b *tokenBucket) middleware.Middleware {
+// 客户端标识方式；未经校验的请求头（API key、User-Agent）可随意轮换，一律不作为分桶依据。
+const (
+	rateKeyIP     = "ip"
+	rateKeyAPIKey = "api_key" // 经 server.auth 校验的 token/mTLS 身份，否则按 IP
+)
+
+// maxRateBuckets 令牌桶数量上限；达到上限且回收后仍无空位时，新客户端共用 overflow 桶。
+const maxRateBuckets = 100000
+
+// limiterEntry 单个客户端的令牌桶及最近访问时间。
+type limiterEntry struct {
+	bucket   *tokenBucket
+	lastSeen time.Time
+}
+
+// RateLimiter 按客户端（IP/已校验的凭据身份）分桶的限流器，HTTP 与 gRPC 共用同一实例。
+// 配置取自 conf.Runtime，热更新后清空已有令牌桶并按新配置生效。
+type RateLimiter struct {
+	rt   *conf.Runtime
+	auth *Authenticator // 校验 token/mTLS 身份（未配置鉴权时为 nil）
+
+	mu        sync.Mutex
+	cfg       *conf.Server_RateLimit // 当前生效的配置快照
+	set       limitSettings
+	buckets   map[string]*limiterEntry
+	overflow  *tokenBucket // 桶数量达到上限后新客户端共用
+	lastSweep time.Time
+}
+
+// limitSettings 由配置快照归一化得到的限流设置。
+type limitSettings struct {
+	rps            float64
+	burst          int
+	key            string
+	idle           time.Duration
+	trustForwarded bool
+}
+
+// NewRateLimiter 依据 server.rate_limit（经 conf.Runtime 热更新）构造限流器；rps<=0 时不限流。
+// key=api_key 时借助 auth 校验凭据，仅已校验的身份单独分桶；key 取值非法时报错。
+func NewRateLimiter(rt *conf.Runtime, auth *Authenticator) (*RateLimiter, error) {
+	if err := rt.RateLimit().Validate(); err != nil {
+		return nil, err
+	}
+	l := &RateLimiter{rt: rt, auth: auth}
+	l.configure(rt.RateLimit())
+	return l, nil
+}
+
+// configure 应用新的配置快照（调用方持有 mu 或处于构造阶段）。
+func (l *RateLimiter) configure(rl *conf.Server_RateLimit) {
+	set := limitSettings{
+		rps:            rl.GetRps(),
+		burst:          int(rl.GetBurst()),
+		key:            strings.ToLower(rl.GetKey()),
+		idle:           rl.GetIdleTimeout().AsDuration(),
+		trustForwarded: rl.GetTrustForwarded(),
+	}
+	if set.burst <= 0 {
+		set.burst = int(math.Max(1, set.rps*2))
+	}
+	if set.idle <= 0 {
+		set.idle = 10 * time.Minute
+	}
+	l.cfg = rl
+	l.set = set
+	l.buckets = map[string]*limiterEntry{}
+	l.overflow = newTokenBucket(set.rps, set.burst)
+	l.lastSweep = time.Now()
+}
+
+// settings 返回当前生效的设置；配置热更新后先重新配置。
+func (l *RateLimiter) settings() limitSettings {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if cur := l.rt.RateLimit(); cur != l.cfg {
+		l.configure(cur)
+	}
+	return l.set
+}
+
+// bucket 返回客户端的令牌桶，并顺带回收空闲超时的客户端；桶数量达到上限时新客户端共用 overflow 桶。
+func (l *RateLimiter) bucket(key string, now time.Time) *tokenBucket {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if now.Sub(l.lastSweep) >= l.set.idle {
+		l.sweep(now)
+	}
+	e, ok := l.buckets[key]
+	if !ok {
+		if len(l.buckets) >= maxRateBuckets {
+			l.sweep(now)
+			if len(l.buckets) >= maxRateBuckets {
+				return l.overflow
+			}
+		}
+		e = &limiterEntry{bucket: newTokenBucket(l.set.rps, l.set.burst)}
+		l.buckets[key] = e
+	}
+	e.lastSeen = now
+	return e.bucket
+}
+
+// sweep 回收空闲超时的客户端（调用方持有 mu）。
+func (l *RateLimiter) sweep(now time.Time) {
+	for k, e := range l.buckets {
+		if now.Sub(e.lastSeen) >= l.set.idle {
+			delete(l.buckets, k)
+		}
+	}
+	l.lastSweep = now
+}
+
+// clientKey 按配置提取客户端标识：key=api_key 时仅使用经鉴权校验的身份，其余情况按 IP。
+func (set limitSettings) clientKey(ctx context.Context, tr transport.Transporter, auth *Authenticator) string {
+	if set.key == rateKeyAPIKey {
+		if id := auth.identity(ctx, tr); id != "" {
+			return "id:" + id
+		}
+	}
+	return "ip:" + set.clientIP(ctx, tr)
+}
+
+// clientIP 提取客户端 IP：HTTP 取 RemoteAddr（可选信任转发头），gRPC 取 peer 地址。
+// X-Forwarded-For 取最右一项（由受信任的反向代理追加），左侧各项可由客户端伪造。
+func (set limitSettings) clientIP(ctx context.Context, tr transport.Transporter) string {
+	if set.trustForwarded {
+		if v := tr.RequestHeader().Get("X-Forwarded-For"); v != "" {
+			if i := strings.LastIndex(v, ","); i >= 0 {
+				v = v[i+1:]
+			}
+			return strings.TrimSpace(v)
+		}
+		if v := tr.RequestHeader().Get("X-Real-IP"); v != "" {
+			return strings.TrimSpace(v)
+		}
+	}
+	addr := ""
+	if r, ok := http.RequestFromServerContext(ctx); ok {
+		addr = r.RemoteAddr
+	} else if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		addr = p.Addr.String()
+	}
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return addr
+}
+
+// Middleware 限流中间件：写入 X-RateLimit-* 响应头，超限时返回 429 并附带 Retry-After。
+func (l *RateLimiter) Middleware() middleware.Middleware {
 	return func(next middleware.Handler) middleware.Handler {
 		return func(ctx context.Context, req interface{}) (reply interface{}, err error) {
-			if !b.allow() {
+			tr, ok := transport.FromServerContext(ctx)
+			if !ok {
+				return next(ctx, req)
+			}
+			set := l.settings()
+			if set.rps <= 0 {
+				return next(ctx, req)
+			}
+			b := l.bucket(set.clientKey(ctx, tr, l.auth), time.Now())
+			cost := requestCost(req)
+			allowed, remaining, wait := b.take(time.Now(), cost)
+			h := tr.ReplyHeader()
+			h.Set("X-RateLimit-Limit", strconv.Itoa(set.burst))
+			h.Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+			h.Set("X-RateLimit-Reset", strconv.Itoa(int(math.Ceil(b.resetAfter().Seconds()))))
+			if !allowed {
+				if wait <= 0 {
+					return nil, errors.New(429, "RATE_LIMIT", fmt.Sprintf("batch of %d queries exceeds the rate limit burst of %d", cost, set.burst))
+				}
+				h.Set("Retry-After", strconv.Itoa(ceilSeconds(wait)))
 				return nil, errors.New(429, "RATE_LIMIT", "rate limit exceeded")
 			}
 			return next(ctx, req)
 		}
 	}
 }
+
+// requestCost 请求消耗的令牌数：批量请求按项数计，其余为 1。
+func requestCost(req any) int {
+	n := 1
+	switch r := req.(type) {
+	case *v1.BatchSearchRequest:
+		n = len(r.GetQueries())
+	case *v1.BatchReverseRequest:
+		n = len(r.GetQueries())
+	}
+	return max(n, 1)
+}
+
+// ceilSeconds 向上取整为秒（至少 1 秒，用于 Retry-After）。
+func ceilSeconds(d time.Duration) int {
+	s := int(math.Ceil(d.Seconds()))
+	if s < 1 {
+		return 1
+	}
+	return s
+}
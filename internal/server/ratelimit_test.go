@@ -0,0 +1,177 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	nethttp "net/http"
+	"testing"
+	"time"
+
+	v1 "nominatim-go/api/nominatim/v1"
+	"nominatim-go/internal/conf"
+
+	"github.com/go-kratos/kratos/v2/transport"
+)
+
+// testHeader transport.Header 的 net/http 实现。
+type testHeader nethttp.Header
+
+func (h testHeader) Get(k string) string      { return nethttp.Header(h).Get(k) }
+func (h testHeader) Set(k, v string)          { nethttp.Header(h).Set(k, v) }
+func (h testHeader) Add(k, v string)          { nethttp.Header(h).Add(k, v) }
+func (h testHeader) Values(k string) []string { return nethttp.Header(h).Values(k) }
+func (h testHeader) Keys() []string {
+	keys := make([]string, 0, len(h))
+	for k := range h {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// testTransport 仅携带请求头的服务端 transport；op 为空时为 Search。
+type testTransport struct {
+	op         string
+	req, reply testHeader
+}
+
+func (t *testTransport) Kind() transport.Kind { return transport.KindHTTP }
+func (t *testTransport) Endpoint() string     { return "" }
+func (t *testTransport) Operation() string {
+	if t.op != "" {
+		return t.op
+	}
+	return "/nominatim.v1.NominatimService/Search"
+}
+func (t *testTransport) RequestHeader() transport.Header { return t.req }
+func (t *testTransport) ReplyHeader() transport.Header   { return t.reply }
+
+func newTestTransport(kv ...string) *testTransport {
+	tr := &testTransport{req: testHeader{}, reply: testHeader{}}
+	for i := 0; i+1 < len(kv); i += 2 {
+		tr.req.Set(kv[i], kv[i+1])
+	}
+	return tr
+}
+
+func TestRateLimitClientKey(t *testing.T) {
+	auth := NewAuthenticator(&conf.Server{Auth: &conf.Server_Auth{Groups: []*conf.Server_Auth_Group{
+		{Name: "ops", Operations: []string{"/nominatim.v1.NominatimService/Details"}, Tokens: []string{"secret"}},
+	}}})
+	set := limitSettings{key: rateKeyAPIKey, trustForwarded: true}
+	tests := []struct {
+		name string
+		set  limitSettings
+		auth *Authenticator
+		kv   []string
+		want string
+	}{
+		{"ip", limitSettings{key: rateKeyIP, trustForwarded: true}, auth, []string{"X-Forwarded-For", "10.0.0.1"}, "ip:10.0.0.1"},
+		{"verified bearer", set, auth, []string{"Authorization", "Bearer secret", "X-Forwarded-For", "10.0.0.1"}, "id:token:ops/0"},
+		{"verified api key", set, auth, []string{"X-API-Key", "secret", "X-Forwarded-For", "10.0.0.1"}, "id:token:ops/0"},
+		{"unknown api key falls back to ip", set, auth, []string{"X-API-Key", "rotated-123", "X-Forwarded-For", "10.0.0.1"}, "ip:10.0.0.1"},
+		{"no auth configured", set, nil, []string{"X-API-Key", "secret", "X-Forwarded-For", "10.0.0.1"}, "ip:10.0.0.1"},
+		{"proxy-appended forwarded address", limitSettings{key: rateKeyIP, trustForwarded: true}, auth, []string{"X-Forwarded-For", "1.2.3.4, 10.0.0.1"}, "ip:10.0.0.1"},
+		{"real ip", limitSettings{key: rateKeyIP, trustForwarded: true}, auth, []string{"X-Real-IP", "10.0.0.2"}, "ip:10.0.0.2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.set.clientKey(context.Background(), newTestTransport(tt.kv...), tt.auth); got != tt.want {
+				t.Errorf("clientKey = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewRateLimiterKey(t *testing.T) {
+	tests := []struct {
+		key     string
+		wantErr bool
+	}{
+		{key: ""},
+		{key: "ip"},
+		{key: "API_KEY"},
+		{key: "user_agent", wantErr: true},
+		{key: "api-key", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			rt := conf.NewRuntime(&conf.Bootstrap{Server: &conf.Server{RateLimit: &conf.Server_RateLimit{Rps: 1, Key: tt.key}}})
+			if _, err := NewRateLimiter(rt, nil); (err != nil) != tt.wantErr {
+				t.Fatalf("NewRateLimiter(key=%q) err = %v, wantErr %v", tt.key, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRateLimitBucketCap(t *testing.T) {
+	l, err := NewRateLimiter(conf.NewRuntime(&conf.Bootstrap{Server: &conf.Server{RateLimit: &conf.Server_RateLimit{Rps: 1, Burst: 1}}}), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	for i := 0; i < maxRateBuckets; i++ {
+		l.bucket(fmt.Sprintf("ip:%d", i), now)
+	}
+	if b := l.bucket("ip:new", now); b != l.overflow {
+		t.Fatal("new client beyond the cap should share the overflow bucket")
+	}
+	if len(l.buckets) != maxRateBuckets {
+		t.Fatalf("buckets = %d, want %d", len(l.buckets), maxRateBuckets)
+	}
+	// 空闲超时的桶回收后重新接纳新客户端
+	if b := l.bucket("ip:later", now.Add(l.set.idle)); b == l.overflow {
+		t.Fatal("idle buckets should be reclaimed before falling back to overflow")
+	}
+}
+
+func TestTokenBucketCost(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name   string
+		cost   []int
+		ok     []bool
+		noWait bool // 最后一次被拒绝时不应给出等待时长（超过容量）
+	}{
+		{name: "single", cost: []int{1, 1, 1, 1, 1, 1}, ok: []bool{true, true, true, true, true, false}},
+		{name: "batch within burst", cost: []int{3, 2, 1}, ok: []bool{true, true, false}},
+		{name: "batch beyond remaining", cost: []int{4, 2}, ok: []bool{true, false}},
+		{name: "batch beyond burst", cost: []int{6}, ok: []bool{false}, noWait: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newTokenBucket(1, 5)
+			b.lastRefill = now
+			var wait time.Duration
+			for i, n := range tt.cost {
+				var ok bool
+				ok, _, wait = b.take(now, n)
+				if ok != tt.ok[i] {
+					t.Fatalf("take #%d(%d) = %v, want %v", i, n, ok, tt.ok[i])
+				}
+			}
+			if last := tt.ok[len(tt.ok)-1]; !last && (wait == 0) != tt.noWait {
+				t.Fatalf("wait = %v, noWait %v", wait, tt.noWait)
+			}
+		})
+	}
+}
+
+func TestRequestCost(t *testing.T) {
+	tests := []struct {
+		name string
+		req  any
+		want int
+	}{
+		{name: "single", req: &v1.SearchRequest{Q: "berlin"}, want: 1},
+		{name: "batch search", req: &v1.BatchSearchRequest{Queries: make([]*v1.SearchRequest, 7)}, want: 7},
+		{name: "batch reverse", req: &v1.BatchReverseRequest{Queries: make([]*v1.ReverseRequest, 3)}, want: 3},
+		{name: "empty batch", req: &v1.BatchSearchRequest{}, want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := requestCost(tt.req); got != tt.want {
+				t.Fatalf("requestCost = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
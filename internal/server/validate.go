@@ -0,0 +1,71 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"nominatim-go/internal/biz"
+
+	"buf.build/go/protovalidate"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"google.golang.org/protobuf/proto"
+)
+
+// Validator 按 proto 中的 buf.validate 规则校验请求，HTTP 与 gRPC 共用。
+type Validator struct {
+	v protovalidate.Validator
+}
+
+// NewValidator 创建校验器（规则按消息类型懒编译并缓存）。
+func NewValidator() (*Validator, error) {
+	v, err := protovalidate.New()
+	if err != nil {
+		return nil, err
+	}
+	return &Validator{v: v}, nil
+}
+
+// Middleware 校验请求消息；违反规则时返回 400，metadata 为 字段路径 -> 违规说明（如 osm_ids[0]、queries[3].countrycodes）。
+func (v *Validator) Middleware() middleware.Middleware {
+	return func(next middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (reply interface{}, err error) {
+			if m, ok := req.(proto.Message); ok {
+				if err := v.validate(m); err != nil {
+					return nil, err
+				}
+			}
+			return next(ctx, req)
+		}
+	}
+}
+
+func (v *Validator) validate(m proto.Message) error {
+	err := v.v.Validate(m)
+	if err == nil {
+		return nil
+	}
+	var ve *protovalidate.ValidationError
+	if !errors.As(err, &ve) {
+		// 规则编译/运行期错误属于服务端问题
+		return errors.InternalServer(biz.InternalServer, err.Error())
+	}
+	fields := make(map[string]string, len(ve.Violations))
+	msgs := make([]string, 0, len(ve.Violations))
+	for _, vi := range ve.Violations {
+		field := protovalidate.FieldPathString(vi.Proto.GetField())
+		msg := vi.Proto.GetMessage()
+		if _, ok := fields[field]; ok {
+			fields[field] += "; " + msg
+		} else {
+			fields[field] = msg
+		}
+		if field == "" {
+			msgs = append(msgs, msg)
+		} else {
+			msgs = append(msgs, fmt.Sprintf("Invalid parameter '%s': %s", field, msg))
+		}
+	}
+	return biz.ErrBadParameter("%s.", strings.Join(msgs, ". ")).WithMetadata(fields)
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	v1 "nominatim-go/api/nominatim/v1"
+	"nominatim-go/internal/biz"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// defaultBatchConcurrency 批量请求的默认最大并发数（nominatim.batch_concurrency 未配置时）
+	defaultBatchConcurrency = 8
+	// batchMaxItems 单次批量请求的最大条数（与 proto 校验规则一致）
+	batchMaxItems = 1000
+)
+
+// BatchSearch 批量搜索：逐项复用 Search，有限并发执行，结果与错误按输入顺序返回。
+func (s *NominatimService) BatchSearch(ctx context.Context, req *v1.BatchSearchRequest) (*v1.BatchSearchResponse, error) {
+	queries := req.GetQueries()
+	if err := checkBatchSize(len(queries)); err != nil {
+		return nil, err
+	}
+	items := make([]*v1.BatchSearchItem, len(queries))
+	runBatch(ctx, s.batchConcurrency(), len(queries), func(ctx context.Context, i int) {
+		res, err := s.Search(ctx, queries[i])
+		if err != nil {
+			items[i] = &v1.BatchSearchItem{Outcome: &v1.BatchSearchItem_Error{Error: toBatchError(err)}}
+			return
+		}
+		items[i] = &v1.BatchSearchItem{Outcome: &v1.BatchSearchItem_Result{Result: res}}
+	})
+	return &v1.BatchSearchResponse{Items: items}, nil
+}
+
+// BatchReverse 批量逆地理：逐项复用 Reverse，有限并发执行，结果与错误按输入顺序返回。
+func (s *NominatimService) BatchReverse(ctx context.Context, req *v1.BatchReverseRequest) (*v1.BatchReverseResponse, error) {
+	queries := req.GetQueries()
+	if err := checkBatchSize(len(queries)); err != nil {
+		return nil, err
+	}
+	items := make([]*v1.BatchReverseItem, len(queries))
+	runBatch(ctx, s.batchConcurrency(), len(queries), func(ctx context.Context, i int) {
+		res, err := s.Reverse(ctx, queries[i])
+		if err != nil {
+			items[i] = &v1.BatchReverseItem{Outcome: &v1.BatchReverseItem_Error{Error: toBatchError(err)}}
+			return
+		}
+		items[i] = &v1.BatchReverseItem{Outcome: &v1.BatchReverseItem_Result{Result: res}}
+	})
+	return &v1.BatchReverseResponse{Items: items}, nil
+}
+
+func checkBatchSize(n int) error {
+	if n == 0 {
+		return biz.ErrBadParameter("queries must not be empty")
+	}
+	if n > batchMaxItems {
+		return biz.ErrBadParameter("too many queries in one batch")
+	}
+	return nil
+}
+
+// batchConcurrency 批量请求的最大并发数（conf.Nominatim，可热更新）。
+func (s *NominatimService) batchConcurrency() int {
+	if n := int(s.rt.Nominatim().GetBatchConcurrency()); n > 0 {
+		return n
+	}
+	return defaultBatchConcurrency
+}
+
+// runBatch 以 limit 为上限并发执行 fn；单项失败不影响其他项。
+func runBatch(ctx context.Context, limit, n int, fn func(ctx context.Context, i int)) {
+	var g errgroup.Group
+	g.SetLimit(limit)
+	for i := 0; i < n; i++ {
+		g.Go(func() error {
+			fn(ctx, i)
+			return nil
+		})
+	}
+	_ = g.Wait()
+}
+
+// toBatchError 将错误转换为单项错误（保留 kratos 错误码与原因）。
+func toBatchError(err error) *v1.BatchError {
+	e := errors.FromError(err)
+	return &v1.BatchError{Code: e.Code, Reason: e.Reason, Message: e.Message}
+}
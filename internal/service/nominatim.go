@@ -4,12 +4,13 @@ import (
 	"context"
 	v1 "nominatim-go/api/nominatim/v1"
 	"nominatim-go/internal/biz"
+	"nominatim-go/internal/conf"
 	"nominatim-go/internal/data"
-	"os"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/go-kratos/kratos/v2/errors"
 	"github.com/go-kratos/kratos/v2/log"
 	kratostransport "github.com/go-kratos/kratos/v2/transport"
 	"google.golang.org/protobuf/types/known/emptypb"
@@ -18,81 +19,114 @@ import (
 // NominatimService 实现 RPC 与 HTTP 入口，调用 biz 层。
 type NominatimService struct {
 	v1.UnimplementedNominatimServiceServer
-	log    *log.Helper
-	search *biz.SearchUsecase
-	data   *data.Data
+	log         *log.Helper
+	rt          *conf.Runtime
+	search      *biz.SearchUsecase
+	maintenance *biz.MaintenanceUsecase
+	data        *data.Data
 }
 
 var serviceStartTime = time.Now()
-var licenceText = func() string {
-	if v := strings.TrimSpace(os.Getenv("NOMINATIM_LICENCE")); v != "" {
+
+// 服务行为配置的默认值（conf.Nominatim 未设置时使用）
+const (
+	defaultLicence      = "Data © OpenStreetMap contributors"
+	defaultVersion      = "dev"
+	defaultSearchLimit  = 10
+	defaultSearchMaxCap = 50
+)
+
+func NewNominatimService(logger log.Logger, rt *conf.Runtime, search *biz.SearchUsecase, maintenance *biz.MaintenanceUsecase, data *data.Data) *NominatimService {
+	return &NominatimService{log: log.NewHelper(logger), rt: rt, search: search, maintenance: maintenance, data: data}
+}
+
+// licence 当前许可证说明（可热更新）。
+func (s *NominatimService) licence() string {
+	if v := s.rt.Nominatim().GetLicence(); v != "" {
 		return v
 	}
-	return "Data © OpenStreetMap contributors"
-}()
-var serviceVersion = func() string {
-	if v := strings.TrimSpace(os.Getenv("NOMINATIM_VERSION")); v != "" {
+	return defaultLicence
+}
+
+// acceptLanguage 确定请求的语言偏好：accept_language 参数、Locales、请求头 Accept-Language（HTTP 与 gRPC metadata），
+// 均未指定时使用服务默认语言（conf.Nominatim.default_language）。
+func (s *NominatimService) acceptLanguage(ctx context.Context, param string, locales *v1.Locales) string {
+	if v := strings.TrimSpace(param); v != "" {
 		return v
 	}
-	return "dev"
-}()
+	if codes := locales.GetCodes(); len(codes) > 0 {
+		return strings.Join(codes, ",")
+	}
+	if tr, ok := kratostransport.FromServerContext(ctx); ok {
+		if v := strings.TrimSpace(tr.RequestHeader().Get("Accept-Language")); v != "" {
+			return v
+		}
+	}
+	return s.rt.Nominatim().GetDefaultLanguage()
+}
 
-func NewNominatimService(logger log.Logger, search *biz.SearchUsecase, data *data.Data) *NominatimService {
-	return &NominatimService{log: log.NewHelper(logger), search: search, data: data}
+// mapError 将仓储/驱动错误映射为 biz 中的类型化错误：kratos 错误原样返回，取消、超时与数据库不可用分别映射，
+// 其余记录日志后返回通用 500，不向客户端暴露驱动错误信息。
+func (s *NominatimService) mapError(ctx context.Context, err error) error {
+	var se *errors.Error
+	switch {
+	case errors.As(err, &se):
+		return se
+	case data.IsCanceled(err):
+		return biz.ErrCanceled
+	case data.IsTimeout(err):
+		return biz.ErrTimeout
+	case data.IsUnavailable(err):
+		s.log.WithContext(ctx).Errorf("database unavailable: %v", err)
+		return biz.ErrUnavailable
+	}
+	s.log.WithContext(ctx).Errorf("query failed: %v", err)
+	return biz.ErrInternalServer
 }
 
-func (s *NominatimService) Search(ctx context.Context, req *v1.SearchRequest) (*v1.SearchResponse, error) {
-	s.log.WithContext(ctx).Infof("Search q=%s", req.GetQ())
-	// 默认分页与上限
-	limit := int(req.GetLimit())
+// searchLimit 按配置的默认值与上限归一化返回条数。
+func (s *NominatimService) searchLimit(limit int) int {
+	n := s.rt.Nominatim()
 	if limit <= 0 {
-		limit = 10
+		limit = int(n.GetDefaultLimit())
+		if limit <= 0 {
+			limit = defaultSearchLimit
+		}
+	}
+	maxLimit := int(n.GetMaxLimit())
+	if maxLimit <= 0 {
+		maxLimit = defaultSearchMaxCap
 	}
-	if limit > 50 {
-		limit = 50
+	if limit > maxLimit {
+		limit = maxLimit
 	}
+	return limit
+}
+
+func (s *NominatimService) Search(ctx context.Context, req *v1.SearchRequest) (*v1.SearchResponse, error) {
+	s.log.WithContext(ctx).Infof("Search q=%s", req.GetQ())
+	// 默认分页与上限（conf.Nominatim）
+	limit := s.searchLimit(int(req.GetLimit()))
 	offset := int(req.GetOffset())
 	if offset < 0 {
 		offset = 0
 	}
-	// 兼容：若 accept_language 为空，则回退到 HTTP Header: Accept-Language
-	acceptLang := req.GetAcceptLanguage()
-	if strings.TrimSpace(acceptLang) == "" {
-		if tr, ok := kratostransport.FromServerContext(ctx); ok {
-			// 使用通用接口获取请求头
-			if headerer, hok := tr.(interface{ RequestHeader() map[string]string }); hok && headerer.RequestHeader() != nil {
-				if v, ok := headerer.RequestHeader()["Accept-Language"]; ok && v != "" {
-					acceptLang = v
-				}
-			}
-		}
-	}
-	// 兼容：解析查询参数 viewbox=left,top,right,bottom（当结构体为空时回填）
-	vleft, vtop, vright, vbottom := req.GetViewbox().GetLeft(), req.GetViewbox().GetTop(), req.GetViewbox().GetRight(), req.GetViewbox().GetBottom()
-	if vleft == 0 && vtop == 0 && vright == 0 && vbottom == 0 {
-		if tr, ok := kratostransport.FromServerContext(ctx); ok {
-			if urlGetter, uok := tr.(interface{ RequestQuery(key string) string }); uok {
-				if vb := strings.TrimSpace(urlGetter.RequestQuery("viewbox")); vb != "" {
-					parts := strings.Split(vb, ",")
-					if len(parts) == 4 {
-						vleft = parseFloatSafe(parts[0])
-						vtop = parseFloatSafe(parts[1])
-						vright = parseFloatSafe(parts[2])
-						vbottom = parseFloatSafe(parts[3])
-					}
-				}
-			}
-		}
-	}
 	// 规范化 countrycodes：去空格、小写
 	cc := strings.ToLower(strings.ReplaceAll(req.GetCountrycodes(), " ", ""))
 	items, err := s.search.Search(ctx, biz.SearchParams{
 		Q:                req.GetQ(),
+		Amenity:          strings.TrimSpace(req.GetAmenity()),
+		Street:           strings.TrimSpace(req.GetStreet()),
+		City:             strings.TrimSpace(req.GetCity()),
+		County:           strings.TrimSpace(req.GetCounty()),
+		State:            strings.TrimSpace(req.GetState()),
+		Country:          strings.TrimSpace(req.GetCountry()),
+		PostalCode:       strings.TrimSpace(req.GetPostalcode()),
 		CountryCodes:     cc,
 		Limit:            limit,
 		Offset:           offset,
 		AddressDetails:   req.GetAddressdetails(),
-		AcceptLanguage:   acceptLang,
+		AcceptLanguage:   s.acceptLanguage(ctx, req.GetAcceptLanguage(), req.GetLocales()),
 		FeatureType:      req.GetFeaturetype(),
 		Dedupe:           req.GetDedupe(),
 		Bounded:          req.GetBounded(),
@@ -102,17 +136,17 @@ func (s *NominatimService) Search(ctx context.Context, req *v1.SearchRequest) (*
 		NameDetails:      req.GetNamedetails(),
 		ExcludePlaceIDs:  req.GetExcludePlaceIds(),
 		Layers:           splitCSV(req.GetLayer()),
-		ViewBoxLeft:      vleft,
-		ViewBoxTop:       vtop,
-		ViewBoxRight:     vright,
-		ViewBoxBottom:    vbottom,
+		ViewBoxLeft:      req.GetViewbox().GetLeft(),
+		ViewBoxTop:       req.GetViewbox().GetTop(),
+		ViewBoxRight:     req.GetViewbox().GetRight(),
+		ViewBoxBottom:    req.GetViewbox().GetBottom(),
 	})
 	if err != nil {
-		return nil, err
+		return nil, s.mapError(ctx, err)
 	}
 	results := make([]*v1.Place, 0, len(items))
 	for _, it := range items {
-		results = append(results, mapPlaceWithLocale(it, req.GetAcceptLanguage()))
+		results = append(results, s.mapPlace(it))
 	}
 	return &v1.SearchResponse{Results: results}, nil
 }
@@ -123,7 +157,7 @@ func (s *NominatimService) Reverse(ctx context.Context, req *v1.ReverseRequest)
 		Lon:              req.GetLon(),
 		Zoom:             int(req.GetZoom()),
 		AddressDetails:   req.GetAddressdetails(),
-		AcceptLanguage:   req.GetAcceptLanguage(),
+		AcceptLanguage:   s.acceptLanguage(ctx, req.GetAcceptLanguage(), req.GetLocales()),
 		PolygonGeoJSON:   req.GetPolygonGeojson(),
 		PolygonThreshold: req.GetPolygonThreshold(),
 		ExtraTags:        req.GetExtratags(),
@@ -131,30 +165,30 @@ func (s *NominatimService) Reverse(ctx context.Context, req *v1.ReverseRequest)
 		Layers:           splitCSV(req.GetLayer()),
 	})
 	if err != nil {
-		return nil, err
+		return nil, s.mapError(ctx, err)
 	}
 	if it == nil {
-		return &v1.ReverseResponse{}, nil
+		return nil, biz.ErrNotFound
 	}
-	return &v1.ReverseResponse{Result: mapPlaceWithLocale(it, req.GetAcceptLanguage())}, nil
+	return &v1.ReverseResponse{Result: s.mapPlace(it)}, nil
 }
 
 func (s *NominatimService) Lookup(ctx context.Context, req *v1.LookupRequest) (*v1.LookupResponse, error) {
 	it, err := s.search.Lookup(ctx, biz.LookupParams{
 		OSMIDs:           req.GetOsmIds(),
 		AddressDetails:   req.GetAddressdetails(),
-		AcceptLanguage:   req.GetAcceptLanguage(),
+		AcceptLanguage:   s.acceptLanguage(ctx, req.GetAcceptLanguage(), req.GetLocales()),
 		PolygonGeoJSON:   req.GetPolygonGeojson(),
 		PolygonThreshold: req.GetPolygonThreshold(),
 		ExtraTags:        req.GetExtratags(),
 		NameDetails:      req.GetNamedetails(),
 	})
 	if err != nil {
-		return nil, err
+		return nil, s.mapError(ctx, err)
 	}
 	results := make([]*v1.Place, 0, len(it))
 	for _, p := range it {
-		results = append(results, mapPlaceWithLocale(p, req.GetAcceptLanguage()))
+		results = append(results, s.mapPlace(p))
 	}
 	return &v1.LookupResponse{Results: results}, nil
 }
@@ -169,139 +203,199 @@ func (s *NominatimService) Status(ctx context.Context, _ *v1.StatusRequest) (*v1
 			dbStatus = "unavailable"
 		}
 	}
-	return &v1.StatusResponse{Version: serviceVersion, DbStatus: dbStatus, Uptime: uptime}, nil
+	version := s.rt.Nominatim().GetVersion()
+	if version == "" {
+		version = defaultVersion
+	}
+	return &v1.StatusResponse{Version: version, DbStatus: dbStatus, Uptime: uptime}, nil
 }
 
 func (s *NominatimService) Details(ctx context.Context, req *v1.DetailsRequest) (*v1.DetailsResponse, error) {
-	if strings.TrimSpace(os.Getenv("NOMINATIM_ENABLE_DETAILS")) == "0" {
-		return &v1.DetailsResponse{}, nil
+	if !s.rt.Nominatim().DetailsEnabled() {
+		return nil, errors.Forbidden(biz.Forbidden, "Details endpoint is disabled.")
 	}
+	// osm_id 可为 "W123"，或配合 osmtype 的纯数字（HTTP 参数名 osmid 由查询解析器映射到 osm_id）
 	id := strings.TrimSpace(req.GetOsmId())
-	if id == "" {
-		return &v1.DetailsResponse{}, nil
+	osmType := req.GetOsmtype()
+	if id != "" && strings.ContainsAny(id[:1], "NWRnwr") {
+		osmType, id = id[:1], id[1:]
+	}
+	var osmID int64
+	if id != "" {
+		var err error
+		if osmID, err = strconv.ParseInt(id, 10, 64); err != nil {
+			return nil, biz.ErrBadParameter("Parameter 'osmid' must be a number.")
+		}
 	}
-	res, err := s.search.Lookup(ctx, biz.LookupParams{
-		OSMIDs:           []string{id},
-		AddressDetails:   req.GetAddressdetails(),
-		AcceptLanguage:   req.GetAcceptLanguage(),
-		PolygonGeoJSON:   true,
-		PolygonThreshold: 0,
-		ExtraTags:        true,
-		NameDetails:      true,
+	linked := true
+	if req.Linkedplaces != nil {
+		linked = req.GetLinkedplaces()
+	}
+	d, err := s.search.Details(ctx, biz.DetailsParams{
+		PlaceID:        req.GetPlaceId(),
+		OSMType:        osmType,
+		OSMID:          osmID,
+		Class:          strings.TrimSpace(req.GetClass()),
+		AddressDetails: req.GetAddressdetails(),
+		Keywords:       req.GetKeywords(),
+		LinkedPlaces:   linked,
+		PolygonGeoJSON: req.GetPolygonGeojson(),
+		AcceptLanguage: s.acceptLanguage(ctx, req.GetAcceptLanguage(), nil),
 	})
 	if err != nil {
-		return nil, err
+		return nil, s.mapError(ctx, err)
+	}
+	if d == nil {
+		return nil, errors.NotFound(biz.NotFound, "No place with that OSM ID found.")
+	}
+	res := &v1.DetailsResponse{
+		Result:               s.mapPlace(d.Place),
+		ParentPlaceId:        d.ParentPlaceID,
+		LinkedPlaceId:        d.LinkedPlaceID,
+		AdminLevel:           uint32(d.AdminLevel),
+		RankAddress:          uint32(d.Place.RankAddress),
+		RankSearch:           uint32(d.Place.RankSearch),
+		IndexedDate:          d.IndexedDate,
+		Names:                d.Place.NameDetails,
+		Addresstags:          d.AddressTags,
+		Housenumber:          d.Place.HouseNumber,
+		CalculatedPostcode:   d.CalculatedPostcode,
+		CountryCode:          d.Place.CountryCode,
+		CalculatedImportance: d.CalculatedImportance,
+		CalculatedWikipedia:  d.Wikipedia,
+		Isarea:               d.IsArea,
+		Address:              mapDetailsLines(d.Address),
+		LinkedPlaces:         mapDetailsLines(d.LinkedPlaces),
+		Localname:            d.Place.LocalName,
+	}
+	if req.GetKeywords() {
+		res.Keywords = &v1.DetailsKeywords{Name: mapKeywords(d.NameKeywords), Address: mapKeywords(d.AddressKeywords)}
+	}
+	return res, nil
+}
+
+func mapDetailsLines(lines []biz.DetailsAddressLine) []*v1.DetailsAddressLine {
+	out := make([]*v1.DetailsAddressLine, 0, len(lines))
+	for _, l := range lines {
+		out = append(out, &v1.DetailsAddressLine{
+			Localname:   l.LocalName,
+			PlaceId:     l.PlaceID,
+			OsmType:     l.OSMType,
+			OsmId:       l.OSMID,
+			PlaceType:   l.PlaceType,
+			Class:       l.Category,
+			Type:        l.Type,
+			AdminLevel:  uint32(l.AdminLevel),
+			RankAddress: uint32(l.RankAddress),
+			Distance:    l.Distance,
+			Isaddress:   l.IsAddress,
+		})
 	}
-	if len(res) == 0 {
-		return &v1.DetailsResponse{}, nil
+	return out
+}
+
+func mapKeywords(kws []biz.Keyword) []*v1.DetailsKeyword {
+	out := make([]*v1.DetailsKeyword, 0, len(kws))
+	for _, k := range kws {
+		out = append(out, &v1.DetailsKeyword{Id: k.ID, Token: k.Token})
 	}
-	return &v1.DetailsResponse{Result: mapPlaceWithLocale(res[0], req.GetAcceptLanguage())}, nil
+	return out
 }
 
 func (s *NominatimService) Deletable(ctx context.Context, _ *emptypb.Empty) (*v1.DeletableResponse, error) {
-	if strings.TrimSpace(os.Getenv("NOMINATIM_ENABLE_MAINTENANCE")) == "0" {
+	if !s.rt.Nominatim().MaintenanceEnabled() {
 		return &v1.DeletableResponse{PlaceIds: []int64{}}, nil
 	}
-	// 最小实现：当使用 PostgreSQL 时，返回 placex 中 osm 更新可能导致孤立的最近修改对象（示例逻辑：importance 为 NULL 或 0 的若干条）
-	ids := []int64{}
-	if s.data != nil && s.data.SQLDB() != nil {
-		db := s.data.SQLDB()
-		rowQuery := `SELECT place_id FROM placex WHERE COALESCE(importance, 0) = 0 ORDER BY place_id DESC LIMIT 100`
-		rows, err := db.QueryContext(ctx, rowQuery)
-		if err == nil {
-			defer rows.Close()
-			for rows.Next() {
-				var id int64
-				if err := rows.Scan(&id); err == nil {
-					ids = append(ids, id)
-				}
-			}
+	items, err := s.maintenance.Deletable(ctx)
+	if err != nil {
+		return nil, s.mapError(ctx, err)
+	}
+	res := &v1.DeletableResponse{PlaceIds: []int64{}, Objects: make([]*v1.DeletableObject, 0, len(items))}
+	for _, it := range items {
+		if it.PlaceID > 0 {
+			res.PlaceIds = append(res.PlaceIds, it.PlaceID)
 		}
+		res.Objects = append(res.Objects, &v1.DeletableObject{
+			PlaceId:     it.PlaceID,
+			CountryCode: it.CountryCode,
+			Name:        it.Name,
+			OsmId:       it.OSMID,
+			OsmType:     it.OSMType,
+			Class:       it.Class,
+			Type:        it.Type,
+		})
 	}
-	return &v1.DeletableResponse{PlaceIds: ids}, nil
+	return res, nil
 }
 
-func (s *NominatimService) Polygons(ctx context.Context, _ *emptypb.Empty) (*v1.PolygonsResponse, error) {
-	if strings.TrimSpace(os.Getenv("NOMINATIM_ENABLE_MAINTENANCE")) == "0" {
+func (s *NominatimService) Polygons(ctx context.Context, req *v1.PolygonsRequest) (*v1.PolygonsResponse, error) {
+	if !s.rt.Nominatim().MaintenanceEnabled() {
 		return &v1.PolygonsResponse{PlaceIds: []int64{}}, nil
 	}
-	// 最小实现：当使用 PostgreSQL 时，返回 polygon 无效的 place_id 列表（最多 100 条）
-	ids := []int64{}
-	if s.data != nil && s.data.SQLDB() != nil {
-		db := s.data.SQLDB()
-		rowQuery := `SELECT place_id FROM placex WHERE polygon IS NOT NULL AND NOT ST_IsValid(polygon) LIMIT 100`
-		rows, err := db.QueryContext(ctx, rowQuery)
-		if err == nil {
-			defer rows.Close()
-			for rows.Next() {
-				var id int64
-				if err := rows.Scan(&id); err == nil {
-					ids = append(ids, id)
-				}
-			}
+	items, err := s.maintenance.Polygons(ctx, biz.PolygonsParams{
+		Days:    int(req.GetDays()),
+		Reduced: req.GetReduced(),
+		Class:   strings.TrimSpace(req.GetClass()),
+	})
+	if err != nil {
+		return nil, s.mapError(ctx, err)
+	}
+	res := &v1.PolygonsResponse{PlaceIds: []int64{}, Polygons: make([]*v1.PolygonError, 0, len(items))}
+	for _, it := range items {
+		if it.PlaceID > 0 {
+			res.PlaceIds = append(res.PlaceIds, it.PlaceID)
+		}
+		pe := &v1.PolygonError{
+			OsmType:      it.OSMType,
+			OsmId:        it.OSMID,
+			Class:        it.Class,
+			Type:         it.Type,
+			Name:         it.Name,
+			CountryCode:  it.CountryCode,
+			Errormessage: it.ErrorMessage,
+			Updated:      it.Updated,
+		}
+		if it.ErrorLat != nil && it.ErrorLon != nil {
+			pe.ErrorLocation = &v1.Point{Lat: *it.ErrorLat, Lon: *it.ErrorLon}
 		}
+		res.Polygons = append(res.Polygons, pe)
 	}
-	return &v1.PolygonsResponse{PlaceIds: ids}, nil
+	return res, nil
 }
 
-func mapPlaceWithLocale(it *biz.SearchPlace, acceptLanguage string) *v1.Place {
+// mapPlace 将 biz 结果转换为 v1.Place（展示名称已在 usecase 中按语言偏好拼接）。
+func (s *NominatimService) mapPlace(it *biz.SearchPlace) *v1.Place {
 	// address rows
 	addrRows := make([]*v1.AddressRow, 0, len(it.AddressRows))
 	for _, r := range it.AddressRows {
 		addrRows = append(addrRows, &v1.AddressRow{
-			Name:       r.Name,
-			Type:       r.Component,
-			AdminLevel: r.AdminLevel,
-			Rank:       r.Rank,
+			Name:        r.LocalName,
+			Type:        r.Component,
+			AdminLevel:  r.AdminLevel,
+			Rank:        r.Rank,
+			PlaceId:     r.PlaceID,
+			Isaddress:   r.IsAddress,
+			CountryCode: r.CountryCode,
 		})
 	}
-	// 选择 display name：
-	// 1) name:<lang-REGION> 精确匹配；2) name:<lang> 回退；3) int_name；4) 基础 name
-	display := it.Name
-	langs := parseAcceptLanguages(acceptLanguage)
-	if len(langs) > 0 && len(it.NameDetails) > 0 {
-		chosen := ""
-		// 精确匹配 lang-REGION
-		for _, lang := range langs {
-			if strings.Contains(lang, "-") {
-				if v, ok := it.NameDetails["name:"+lang]; ok && v != "" {
-					chosen = v
-					break
-				}
-			}
-		}
-		// 回退到 lang 主语言
-		if chosen == "" {
-			for _, lang := range langs {
-				base := lang
-				if i := strings.Index(lang, "-"); i >= 0 {
-					base = lang[:i]
-				}
-				if v, ok := it.NameDetails["name:"+base]; ok && v != "" {
-					chosen = v
-					break
-				}
-			}
-		}
-		if chosen == "" {
-			if v, ok := it.NameDetails["int_name"]; ok && v != "" {
-				chosen = v
-			} else if v, ok := it.NameDetails["name"]; ok && v != "" {
-				chosen = v
-			}
-		}
-		if chosen != "" {
-			display = chosen
+	var address map[string]string
+	if len(it.Address) > 0 {
+		address = make(map[string]string, len(it.Address))
+		for _, a := range it.Address {
+			address[a.Key] = a.Value
 		}
 	}
 	return &v1.Place{
-		Licence:        licenceText,
+		Licence:        s.licence(),
 		PlaceId:        it.PlaceID,
 		OsmId:          it.OSMID,
 		OsmType:        it.OSMType,
 		Category:       it.Category,
 		Type:           it.Type,
-		DisplayName:    display,
+		DisplayName:    it.DisplayName,
+		Name:           it.LocalName,
+		PlaceRank:      uint32(it.RankSearch),
+		Addresstype:    it.AddressType,
 		Importance:     it.Importance,
 		Centroid:       &v1.Point{Lat: it.Lat, Lon: it.Lon},
 		Boundingbox:    &v1.BoundingBox{South: it.BBoxSouth, North: it.BBoxNorth, West: it.BBoxWest, East: it.BBoxEast},
@@ -309,29 +403,10 @@ func mapPlaceWithLocale(it *biz.SearchPlace, acceptLanguage string) *v1.Place {
 		PolygonGeojson: it.PolygonGeoJSON,
 		Extratags:      it.ExtraTags,
 		Namedetails:    it.NameDetails,
+		Address:        address,
 	}
 }
 
-func parseAcceptLanguages(s string) []string {
-	if s == "" {
-		return nil
-	}
-	parts := strings.Split(s, ",")
-	langs := make([]string, 0, len(parts))
-	for _, p := range parts {
-		p = strings.TrimSpace(p)
-		if p == "" {
-			continue
-		}
-		// 取语言部分（去掉 ;q=）
-		if i := strings.Index(p, ";"); i >= 0 {
-			p = p[:i]
-		}
-		langs = append(langs, p)
-	}
-	return langs
-}
-
 func splitCSV(s string) []string {
 	if s == "" {
 		return nil
@@ -349,15 +424,3 @@ func splitCSV(s string) []string {
 	}
 	return out
 }
-
-func parseFloatSafe(s string) float64 {
-	s = strings.TrimSpace(s)
-	if s == "" {
-		return 0
-	}
-	// 使用 ParseFloat 前先替换可能的空格
-	if v, err := strconv.ParseFloat(s, 64); err == nil {
-		return v
-	}
-	return 0
-}
@@ -0,0 +1,148 @@
+// Package client 是 NominatimService 的 Go SDK：gRPC 与 HTTP 两种传输实现同一个 Client 接口，
+// 统一提供请求构造（NewSearchRequest 等）、429/503 退避重试（遵循 Retry-After）、客户端限流与调用截止时间。
+// 测试可使用 clienttest 子包提供的内存假服务。
+package client
+
+import (
+	"context"
+	"time"
+
+	v1 "nominatim-go/api/nominatim/v1"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// Client NominatimService 客户端；gRPC（NewGRPC）与 HTTP（NewHTTP）实现行为一致，可并发使用。
+type Client interface {
+	// Search 名称/地址/类型搜索
+	Search(ctx context.Context, req *v1.SearchRequest) (*v1.SearchResponse, error)
+	// Reverse 逆地理编码；无结果时返回 404（errors.IsNotFound）
+	Reverse(ctx context.Context, req *v1.ReverseRequest) (*v1.ReverseResponse, error)
+	// Lookup 依据 OSM ID 批量查询
+	Lookup(ctx context.Context, req *v1.LookupRequest) (*v1.LookupResponse, error)
+	// Details 对象详情
+	Details(ctx context.Context, req *v1.DetailsRequest) (*v1.DetailsResponse, error)
+	// Status 服务状态
+	Status(ctx context.Context) (*v1.StatusResponse, error)
+	// BatchSearch 批量搜索（按输入顺序逐项返回结果或错误）
+	BatchSearch(ctx context.Context, req *v1.BatchSearchRequest) (*v1.BatchSearchResponse, error)
+	// BatchReverse 批量逆地理（按输入顺序逐项返回结果或错误）
+	BatchReverse(ctx context.Context, req *v1.BatchReverseRequest) (*v1.BatchReverseResponse, error)
+	// Deletable 可删除对象列表（维护用途）
+	Deletable(ctx context.Context) (*v1.DeletableResponse, error)
+	// Polygons 问题多边形列表（维护用途）
+	Polygons(ctx context.Context, req *v1.PolygonsRequest) (*v1.PolygonsResponse, error)
+	// Close 释放底层连接
+	Close() error
+}
+
+// conn 单次调用的传输实现（不含重试/限流）；出错时返回的错误应携带 Retry-After（见 withRetryAfter）。
+type conn interface {
+	Search(ctx context.Context, req *v1.SearchRequest) (*v1.SearchResponse, error)
+	Reverse(ctx context.Context, req *v1.ReverseRequest) (*v1.ReverseResponse, error)
+	Lookup(ctx context.Context, req *v1.LookupRequest) (*v1.LookupResponse, error)
+	Details(ctx context.Context, req *v1.DetailsRequest) (*v1.DetailsResponse, error)
+	Status(ctx context.Context, req *v1.StatusRequest) (*v1.StatusResponse, error)
+	BatchSearch(ctx context.Context, req *v1.BatchSearchRequest) (*v1.BatchSearchResponse, error)
+	BatchReverse(ctx context.Context, req *v1.BatchReverseRequest) (*v1.BatchReverseResponse, error)
+	Deletable(ctx context.Context, req *emptypb.Empty) (*v1.DeletableResponse, error)
+	Polygons(ctx context.Context, req *v1.PolygonsRequest) (*v1.PolygonsResponse, error)
+	Close() error
+}
+
+// client 在传输实现之上统一处理截止时间、限流与重试。
+type client struct {
+	conn    conn
+	opts    options
+	limiter *limiter
+}
+
+func newClient(c conn, o options) *client {
+	cl := &client{conn: c, opts: o}
+	if o.rps > 0 {
+		cl.limiter = newLimiter(o.rps, o.burst)
+	}
+	return cl
+}
+
+// invoke 执行一次逻辑调用：ctx 无截止时间时套用 timeout（覆盖全部重试），
+// 每次尝试前等待限流令牌，429/503 按退避或 Retry-After 重试，等待超出截止时间则直接返回最后一次错误。
+func invoke[Req, Resp any](ctx context.Context, c *client, req Req, call func(context.Context, Req) (Resp, error)) (Resp, error) {
+	if _, ok := ctx.Deadline(); !ok && c.opts.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.opts.timeout)
+		defer cancel()
+	}
+	var zero Resp
+	for attempt := 1; ; attempt++ {
+		if c.limiter != nil {
+			if err := c.limiter.wait(ctx); err != nil {
+				return zero, err
+			}
+		}
+		resp, err := call(ctx, req)
+		if err == nil || attempt >= c.opts.retry.maxAttempts || !retryable(err) {
+			return resp, err
+		}
+		wait := c.opts.retry.backoff(attempt)
+		if ra, ok := retryAfter(err); ok {
+			wait = ra
+		}
+		if dl, ok := ctx.Deadline(); ok && time.Until(dl) < wait {
+			return resp, err
+		}
+		t := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return zero, ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+func (c *client) Search(ctx context.Context, req *v1.SearchRequest) (*v1.SearchResponse, error) {
+	return invoke(ctx, c, req, c.conn.Search)
+}
+
+func (c *client) Reverse(ctx context.Context, req *v1.ReverseRequest) (*v1.ReverseResponse, error) {
+	return invoke(ctx, c, req, c.conn.Reverse)
+}
+
+func (c *client) Lookup(ctx context.Context, req *v1.LookupRequest) (*v1.LookupResponse, error) {
+	return invoke(ctx, c, req, c.conn.Lookup)
+}
+
+func (c *client) Details(ctx context.Context, req *v1.DetailsRequest) (*v1.DetailsResponse, error) {
+	return invoke(ctx, c, req, c.conn.Details)
+}
+
+func (c *client) Status(ctx context.Context) (*v1.StatusResponse, error) {
+	return invoke(ctx, c, &v1.StatusRequest{}, c.conn.Status)
+}
+
+func (c *client) BatchSearch(ctx context.Context, req *v1.BatchSearchRequest) (*v1.BatchSearchResponse, error) {
+	return invoke(ctx, c, req, c.conn.BatchSearch)
+}
+
+func (c *client) BatchReverse(ctx context.Context, req *v1.BatchReverseRequest) (*v1.BatchReverseResponse, error) {
+	return invoke(ctx, c, req, c.conn.BatchReverse)
+}
+
+func (c *client) Deletable(ctx context.Context) (*v1.DeletableResponse, error) {
+	return invoke(ctx, c, &emptypb.Empty{}, c.conn.Deletable)
+}
+
+func (c *client) Polygons(ctx context.Context, req *v1.PolygonsRequest) (*v1.PolygonsResponse, error) {
+	return invoke(ctx, c, req, c.conn.Polygons)
+}
+
+func (c *client) Close() error {
+	return c.conn.Close()
+}
+
+// IsRateLimited 是否为服务端限流（429）；重试次数用尽后仍可能返回该错误。
+func IsRateLimited(err error) bool {
+	return errors.FromError(err).GetCode() == 429
+}
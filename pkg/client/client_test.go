@@ -0,0 +1,144 @@
+package client_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v1 "nominatim-go/api/nominatim/v1"
+	"nominatim-go/pkg/client"
+	"nominatim-go/pkg/client/clienttest"
+
+	"github.com/go-kratos/kratos/v2/errors"
+)
+
+func TestClientRetry(t *testing.T) {
+	tests := []struct {
+		name    string
+		fails   []error
+		opts    []client.Option
+		timeout time.Duration // 调用方 ctx 的截止时间（0 表示不设置）
+		code    int           // 期望的错误码（0 表示成功）
+		calls   int           // 期望的服务端调用次数
+		minWait time.Duration // 期望的最短耗时
+		maxWait time.Duration // 期望的最长耗时（0 表示不检查）
+	}{
+		{
+			name:  "retry 503 and 429",
+			fails: []error{clienttest.Unavailable(), clienttest.RateLimited(0)},
+			opts:  []client.Option{client.WithRetry(3, time.Millisecond, 5*time.Millisecond)},
+			calls: 3,
+		},
+		{
+			name:  "attempts exhausted",
+			fails: []error{clienttest.Unavailable(), clienttest.Unavailable(), clienttest.Unavailable()},
+			opts:  []client.Option{client.WithRetry(2, time.Millisecond, time.Millisecond)},
+			code:  503,
+			calls: 2,
+		},
+		{
+			name:  "retry disabled",
+			fails: []error{clienttest.Unavailable()},
+			opts:  []client.Option{client.WithRetry(1, time.Millisecond, time.Millisecond)},
+			code:  503,
+			calls: 1,
+		},
+		{
+			name:  "not retryable",
+			fails: []error{clienttest.NotFound()},
+			code:  404,
+			calls: 1,
+		},
+		{
+			name:    "retry-after honored",
+			fails:   []error{clienttest.RateLimited(time.Second)},
+			opts:    []client.Option{client.WithRetry(2, time.Millisecond, time.Millisecond)},
+			calls:   2,
+			minWait: 900 * time.Millisecond,
+		},
+		{
+			name:    "retry-after beyond deadline",
+			fails:   []error{clienttest.RateLimited(5 * time.Second)},
+			opts:    []client.Option{client.WithRetry(3, time.Millisecond, time.Millisecond)},
+			timeout: time.Second,
+			code:    429,
+			calls:   1,
+			maxWait: 500 * time.Millisecond,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := clienttest.NewServer(clienttest.Place(1, "R62422", "Berlin, Deutschland", 52.51, 13.39))
+			defer srv.Close()
+			c, err := srv.Client(tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer c.Close()
+			srv.FailNext(tt.fails...)
+
+			ctx := context.Background()
+			if tt.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+			}
+			start := time.Now()
+			resp, err := c.Search(ctx, &v1.SearchRequest{Q: "berlin"})
+			elapsed := time.Since(start)
+			if tt.code == 0 && err != nil || tt.code != 0 && (err == nil || int(errors.FromError(err).GetCode()) != tt.code) {
+				t.Fatalf("err = %v, want code %d", err, tt.code)
+			}
+			if tt.code == 0 && len(resp.GetResults()) != 1 {
+				t.Fatalf("results = %v, want 1", resp.GetResults())
+			}
+			if got := srv.Calls("Search"); got != tt.calls {
+				t.Fatalf("calls = %d, want %d", got, tt.calls)
+			}
+			if elapsed < tt.minWait || tt.maxWait > 0 && elapsed > tt.maxWait {
+				t.Fatalf("elapsed = %v, want in [%v, %v]", elapsed, tt.minWait, tt.maxWait)
+			}
+		})
+	}
+}
+
+func TestClientRateLimit(t *testing.T) {
+	srv := clienttest.NewServer()
+	defer srv.Close()
+
+	t.Run("paced", func(t *testing.T) {
+		c, err := srv.Client(client.WithRateLimit(20, 1))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer c.Close()
+		start := time.Now()
+		for i := 0; i < 3; i++ {
+			if _, err := c.Status(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if d := time.Since(start); d < 90*time.Millisecond {
+			t.Fatalf("3 calls at 20 rps took %v, want >= 100ms", d)
+		}
+	})
+
+	t.Run("deadline before token", func(t *testing.T) {
+		c, err := srv.Client(client.WithRateLimit(1, 1), client.WithTimeout(100*time.Millisecond))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer c.Close()
+		before := srv.Calls("Search")
+		if _, err := c.Search(context.Background(), &v1.SearchRequest{Q: "x"}); err != nil {
+			t.Fatal(err)
+		}
+		_, err = c.Search(context.Background(), &v1.SearchRequest{Q: "x"})
+		if se := errors.FromError(err); se.GetCode() != 429 || se.GetReason() != "CLIENT_RATE_LIMIT" {
+			t.Fatalf("err = %v, want CLIENT_RATE_LIMIT", err)
+		}
+		if got := srv.Calls("Search") - before; got != 1 {
+			t.Fatalf("calls = %d, want 1 (limited call must not reach the server)", got)
+		}
+	})
+}
@@ -0,0 +1,330 @@
+// Package clienttest 提供内存中的 NominatimService 假服务（gRPC over bufconn），
+// 供使用 pkg/client 的代码编写测试：按预置地点应答查询，可覆盖单个方法或注入错误（如 429 + Retry-After）。
+package clienttest
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	v1 "nominatim-go/api/nominatim/v1"
+	"nominatim-go/pkg/client"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const bufSize = 1 << 20
+
+// Server 内存假服务：按预置地点（NewServer/AddPlaces）应答；XxxFunc 非空时替代默认实现（需在发起调用前设置）。
+type Server struct {
+	v1.UnimplementedNominatimServiceServer
+
+	SearchFunc  func(ctx context.Context, req *v1.SearchRequest) (*v1.SearchResponse, error)
+	ReverseFunc func(ctx context.Context, req *v1.ReverseRequest) (*v1.ReverseResponse, error)
+	LookupFunc  func(ctx context.Context, req *v1.LookupRequest) (*v1.LookupResponse, error)
+	DetailsFunc func(ctx context.Context, req *v1.DetailsRequest) (*v1.DetailsResponse, error)
+
+	mu     sync.Mutex
+	places []*v1.Place
+	fails  []error
+	calls  map[string]int
+
+	lis *bufconn.Listener
+	srv *grpc.Server
+}
+
+// NewServer 启动假服务并预置地点。
+func NewServer(places ...*v1.Place) *Server {
+	s := &Server{
+		places: places,
+		calls:  map[string]int{},
+		lis:    bufconn.Listen(bufSize),
+	}
+	s.srv = grpc.NewServer(grpc.UnaryInterceptor(s.intercept))
+	v1.RegisterNominatimServiceServer(s.srv, s)
+	// 与 kratos 服务端一致地提供健康检查（客户端默认启用）
+	healthpb.RegisterHealthServer(s.srv, health.NewServer())
+	go func() { _ = s.srv.Serve(s.lis) }()
+	return s
+}
+
+// Client 创建连接到假服务的 client.Client（重试、限流等选项与真实服务一致地生效）。
+func (s *Server) Client(opts ...client.Option) (client.Client, error) {
+	dialer := grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return s.lis.DialContext(ctx)
+	})
+	opts = append([]client.Option{client.WithDialOptions(dialer)}, opts...)
+	return client.NewGRPC(context.Background(), "passthrough:///bufnet", opts...)
+}
+
+// Close 停止假服务。
+func (s *Server) Close() {
+	s.srv.Stop()
+	_ = s.lis.Close()
+}
+
+// AddPlaces 追加预置地点。
+func (s *Server) AddPlaces(places ...*v1.Place) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.places = append(s.places, places...)
+}
+
+// FailNext 使接下来的调用依次返回 errs（不论方法），用于测试重试与错误处理。
+func (s *Server) FailNext(errs ...error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fails = append(s.fails, errs...)
+}
+
+// Calls 方法（如 "Search"）被调用的次数（含注入错误的调用）。
+func (s *Server) Calls(method string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls[method]
+}
+
+// RateLimited 服务端限流错误（429），retryAfter>0 时如真实服务一样在响应头返回 Retry-After。
+func RateLimited(retryAfter time.Duration) error {
+	e := errors.New(429, "RATE_LIMIT", "rate limit exceeded")
+	if retryAfter > 0 {
+		e = e.WithMetadata(map[string]string{"Retry-After": strconv.Itoa(int(math.Ceil(retryAfter.Seconds())))})
+	}
+	return e
+}
+
+// Unavailable 数据库不可用错误（503）。
+func Unavailable() error {
+	return errors.ServiceUnavailable("UNAVAILABLE", "Database unavailable.")
+}
+
+// NotFound 无结果错误（404，与 /reverse 一致）。
+func NotFound() error {
+	return errors.NotFound("NOT_FOUND", "Unable to geocode")
+}
+
+// intercept 计数、注入错误，并将错误 metadata 中的 Retry-After 写入响应头。
+func (s *Server) intercept(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	method := info.FullMethod[strings.LastIndex(info.FullMethod, "/")+1:]
+	s.mu.Lock()
+	s.calls[method]++
+	var err error
+	if len(s.fails) > 0 {
+		err = s.fails[0]
+		s.fails = s.fails[1:]
+	}
+	s.mu.Unlock()
+	var reply any
+	if err == nil {
+		reply, err = handler(ctx, req)
+	}
+	if err != nil {
+		if ra := errors.FromError(err).GetMetadata()["Retry-After"]; ra != "" {
+			_ = grpc.SetHeader(ctx, metadata.Pairs("retry-after", ra))
+		}
+	}
+	return reply, err
+}
+
+// snapshot 预置地点的副本。
+func (s *Server) snapshot() []*v1.Place {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*v1.Place(nil), s.places...)
+}
+
+func (s *Server) Search(ctx context.Context, req *v1.SearchRequest) (*v1.SearchResponse, error) {
+	if s.SearchFunc != nil {
+		return s.SearchFunc(ctx, req)
+	}
+	return s.search(req), nil
+}
+
+// search 默认实现：查询词（或结构化字段）逐项不区分大小写地包含于 display_name 即匹配。
+func (s *Server) search(req *v1.SearchRequest) *v1.SearchResponse {
+	terms := []string{req.GetQ()}
+	if req.GetQ() == "" {
+		terms = []string{req.GetAmenity(), req.GetStreet(), req.GetCity(), req.GetCounty(), req.GetState(), req.GetCountry(), req.GetPostalcode()}
+	}
+	excluded := map[int64]bool{}
+	for _, id := range req.GetExcludePlaceIds() {
+		excluded[id] = true
+	}
+	limit := int(req.GetLimit())
+	if limit == 0 {
+		limit = 10
+	}
+	out := &v1.SearchResponse{}
+	skip := int(req.GetOffset())
+	for _, p := range s.snapshot() {
+		if excluded[p.GetPlaceId()] || !matches(p.GetDisplayName(), terms) {
+			continue
+		}
+		if skip > 0 {
+			skip--
+			continue
+		}
+		out.Results = append(out.Results, proto.CloneOf(p))
+		if len(out.Results) >= limit {
+			break
+		}
+	}
+	return out
+}
+
+func matches(name string, terms []string) bool {
+	name = strings.ToLower(name)
+	matched := false
+	for _, t := range terms {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if t == "" {
+			continue
+		}
+		if !strings.Contains(name, t) {
+			return false
+		}
+		matched = true
+	}
+	return matched
+}
+
+func (s *Server) Reverse(ctx context.Context, req *v1.ReverseRequest) (*v1.ReverseResponse, error) {
+	if s.ReverseFunc != nil {
+		return s.ReverseFunc(ctx, req)
+	}
+	return s.reverse(req)
+}
+
+// reverse 默认实现：返回质心距离最近的地点；无预置地点时返回 404。
+func (s *Server) reverse(req *v1.ReverseRequest) (*v1.ReverseResponse, error) {
+	var (
+		best *v1.Place
+		dist = math.Inf(1)
+	)
+	for _, p := range s.snapshot() {
+		c := p.GetCentroid()
+		if c == nil {
+			continue
+		}
+		if d := math.Hypot(c.GetLat()-req.GetLat(), c.GetLon()-req.GetLon()); d < dist {
+			best, dist = p, d
+		}
+	}
+	if best == nil {
+		return nil, NotFound()
+	}
+	return &v1.ReverseResponse{Result: proto.CloneOf(best)}, nil
+}
+
+func (s *Server) Lookup(ctx context.Context, req *v1.LookupRequest) (*v1.LookupResponse, error) {
+	if s.LookupFunc != nil {
+		return s.LookupFunc(ctx, req)
+	}
+	out := &v1.LookupResponse{}
+	places := s.snapshot()
+	for _, id := range req.GetOsmIds() {
+		for _, p := range places {
+			if strings.EqualFold(osmRef(p), id) {
+				out.Results = append(out.Results, proto.CloneOf(p))
+				break
+			}
+		}
+	}
+	return out, nil
+}
+
+// osmRef 地点的 OSM 引用（如 "R62422"）。
+func osmRef(p *v1.Place) string {
+	if p.GetOsmType() == "" {
+		return ""
+	}
+	return strings.ToUpper(p.GetOsmType()[:1]) + p.GetOsmId()
+}
+
+func (s *Server) Details(ctx context.Context, req *v1.DetailsRequest) (*v1.DetailsResponse, error) {
+	if s.DetailsFunc != nil {
+		return s.DetailsFunc(ctx, req)
+	}
+	ref := req.GetOsmtype() + req.GetOsmId()
+	for _, p := range s.snapshot() {
+		if (req.GetPlaceId() != 0 && p.GetPlaceId() == req.GetPlaceId()) || (ref != "" && strings.EqualFold(osmRef(p), ref)) {
+			return &v1.DetailsResponse{Result: proto.CloneOf(p), Localname: p.GetName()}, nil
+		}
+	}
+	return nil, errors.NotFound("NOT_FOUND", "No place with that OSM ID found.")
+}
+
+func (s *Server) Status(context.Context, *v1.StatusRequest) (*v1.StatusResponse, error) {
+	return &v1.StatusResponse{Version: "clienttest", DbStatus: "OK", Uptime: "0s"}, nil
+}
+
+func (s *Server) BatchSearch(ctx context.Context, req *v1.BatchSearchRequest) (*v1.BatchSearchResponse, error) {
+	out := &v1.BatchSearchResponse{Items: make([]*v1.BatchSearchItem, 0, len(req.GetQueries()))}
+	for _, q := range req.GetQueries() {
+		res, err := s.Search(ctx, q)
+		if err != nil {
+			out.Items = append(out.Items, &v1.BatchSearchItem{Outcome: &v1.BatchSearchItem_Error{Error: batchError(err)}})
+			continue
+		}
+		out.Items = append(out.Items, &v1.BatchSearchItem{Outcome: &v1.BatchSearchItem_Result{Result: res}})
+	}
+	return out, nil
+}
+
+func (s *Server) BatchReverse(ctx context.Context, req *v1.BatchReverseRequest) (*v1.BatchReverseResponse, error) {
+	out := &v1.BatchReverseResponse{Items: make([]*v1.BatchReverseItem, 0, len(req.GetQueries()))}
+	for _, q := range req.GetQueries() {
+		res, err := s.Reverse(ctx, q)
+		if err != nil {
+			out.Items = append(out.Items, &v1.BatchReverseItem{Outcome: &v1.BatchReverseItem_Error{Error: batchError(err)}})
+			continue
+		}
+		out.Items = append(out.Items, &v1.BatchReverseItem{Outcome: &v1.BatchReverseItem_Result{Result: res}})
+	}
+	return out, nil
+}
+
+func batchError(err error) *v1.BatchError {
+	e := errors.FromError(err)
+	return &v1.BatchError{Code: e.GetCode(), Reason: e.GetReason(), Message: e.GetMessage()}
+}
+
+func (s *Server) Deletable(context.Context, *emptypb.Empty) (*v1.DeletableResponse, error) {
+	return &v1.DeletableResponse{}, nil
+}
+
+func (s *Server) Polygons(context.Context, *v1.PolygonsRequest) (*v1.PolygonsResponse, error) {
+	return &v1.PolygonsResponse{}, nil
+}
+
+// Place 构造预置地点（osmRef 形如 "N123"、"W456"、"R789"）。
+func Place(placeID int64, osmRef, displayName string, lat, lon float64) *v1.Place {
+	var typ string
+	if len(osmRef) > 1 {
+		typ = map[byte]string{'N': "node", 'W': "way", 'R': "relation"}[strings.ToUpper(osmRef[:1])[0]]
+	}
+	if typ == "" {
+		panic(fmt.Sprintf("clienttest: invalid OSM reference %q", osmRef))
+	}
+	name, _, _ := strings.Cut(displayName, ",")
+	return &v1.Place{
+		PlaceId:     placeID,
+		OsmType:     typ,
+		OsmId:       osmRef[1:],
+		DisplayName: displayName,
+		Name:        name,
+		Centroid:    &v1.Point{Lat: lat, Lon: lon},
+	}
+}
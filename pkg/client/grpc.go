@@ -0,0 +1,124 @@
+package client
+
+import (
+	"context"
+
+	v1 "nominatim-go/api/nominatim/v1"
+
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+	kgrpc "github.com/go-kratos/kratos/v2/transport/grpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// grpcConn gRPC 传输：调用生成的 NominatimServiceClient，并从响应 header 读取 retry-after。
+type grpcConn struct {
+	cc *grpc.ClientConn
+	c  v1.NominatimServiceClient
+}
+
+// NewGRPC 连接 gRPC 端点（如 "127.0.0.1:9000"）；未指定 WithTLSConfig 时使用明文连接。
+func NewGRPC(ctx context.Context, endpoint string, opts ...Option) (Client, error) {
+	o := newOptions(opts)
+	dialOpts := o.dialOpts
+	if o.userAgent != "" {
+		dialOpts = append(dialOpts, grpc.WithUserAgent(o.userAgent))
+	}
+	kopts := []kgrpc.ClientOption{
+		kgrpc.WithEndpoint(endpoint),
+		// 截止时间由 invoke 统一控制
+		kgrpc.WithTimeout(0),
+		kgrpc.WithMiddleware(clientMiddleware(o)...),
+		// kgrpc.WithOptions 会覆盖先前的设置，需一次传入
+		kgrpc.WithOptions(dialOpts...),
+	}
+	var (
+		cc  *grpc.ClientConn
+		err error
+	)
+	if o.tlsConf != nil {
+		cc, err = kgrpc.Dial(ctx, append(kopts, kgrpc.WithTLSConfig(o.tlsConf))...)
+	} else {
+		cc, err = kgrpc.DialInsecure(ctx, kopts...)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return newClient(&grpcConn{cc: cc, c: v1.NewNominatimServiceClient(cc)}, o), nil
+}
+
+// clientMiddleware 请求头中间件与调用方追加的中间件（两种传输共用）。
+func clientMiddleware(o options) []middleware.Middleware {
+	ms := make([]middleware.Middleware, 0, len(o.middleware)+1)
+	if len(o.header) > 0 {
+		header := o.header
+		ms = append(ms, func(next middleware.Handler) middleware.Handler {
+			return func(ctx context.Context, req any) (any, error) {
+				if tr, ok := transport.FromClientContext(ctx); ok {
+					for k, v := range header {
+						tr.RequestHeader().Set(k, v)
+					}
+				}
+				return next(ctx, req)
+			}
+		})
+	}
+	return append(ms, o.middleware...)
+}
+
+// unaryGRPC 调用并将 header 中的 retry-after 记入错误。
+func unaryGRPC[Req, Resp any](ctx context.Context, req Req, call func(context.Context, Req, ...grpc.CallOption) (Resp, error)) (Resp, error) {
+	var md metadata.MD
+	resp, err := call(ctx, req, grpc.Header(&md))
+	if err != nil {
+		var zero Resp
+		var ra string
+		if v := md.Get("retry-after"); len(v) > 0 {
+			ra = v[0]
+		}
+		return zero, withRetryAfter(err, ra)
+	}
+	return resp, nil
+}
+
+func (g *grpcConn) Search(ctx context.Context, req *v1.SearchRequest) (*v1.SearchResponse, error) {
+	return unaryGRPC(ctx, req, g.c.Search)
+}
+
+func (g *grpcConn) Reverse(ctx context.Context, req *v1.ReverseRequest) (*v1.ReverseResponse, error) {
+	return unaryGRPC(ctx, req, g.c.Reverse)
+}
+
+func (g *grpcConn) Lookup(ctx context.Context, req *v1.LookupRequest) (*v1.LookupResponse, error) {
+	return unaryGRPC(ctx, req, g.c.Lookup)
+}
+
+func (g *grpcConn) Details(ctx context.Context, req *v1.DetailsRequest) (*v1.DetailsResponse, error) {
+	return unaryGRPC(ctx, req, g.c.Details)
+}
+
+func (g *grpcConn) Status(ctx context.Context, req *v1.StatusRequest) (*v1.StatusResponse, error) {
+	return unaryGRPC(ctx, req, g.c.Status)
+}
+
+func (g *grpcConn) BatchSearch(ctx context.Context, req *v1.BatchSearchRequest) (*v1.BatchSearchResponse, error) {
+	return unaryGRPC(ctx, req, g.c.BatchSearch)
+}
+
+func (g *grpcConn) BatchReverse(ctx context.Context, req *v1.BatchReverseRequest) (*v1.BatchReverseResponse, error) {
+	return unaryGRPC(ctx, req, g.c.BatchReverse)
+}
+
+func (g *grpcConn) Deletable(ctx context.Context, req *emptypb.Empty) (*v1.DeletableResponse, error) {
+	return unaryGRPC(ctx, req, g.c.Deletable)
+}
+
+func (g *grpcConn) Polygons(ctx context.Context, req *v1.PolygonsRequest) (*v1.PolygonsResponse, error) {
+	return unaryGRPC(ctx, req, g.c.Polygons)
+}
+
+func (g *grpcConn) Close() error {
+	return g.cc.Close()
+}
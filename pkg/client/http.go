@@ -0,0 +1,198 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	v1 "nominatim-go/api/nominatim/v1"
+
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// httpConn HTTP 传输：GET 请求的查询参数按 Nominatim v1 语法编码（与服务端 decoder 对应），
+// 响应与错误沿用 kratos 编解码（未指定 format，响应为 protojson）。
+type httpConn struct {
+	cc *khttp.Client
+}
+
+// NewHTTP 连接 HTTP 端点（如 "http://127.0.0.1:8000"；省略 scheme 时按是否配置 TLS 选择 http/https）。
+func NewHTTP(ctx context.Context, endpoint string, opts ...Option) (Client, error) {
+	o := newOptions(opts)
+	kopts := []khttp.ClientOption{
+		khttp.WithEndpoint(endpoint),
+		// 截止时间由 invoke 统一控制
+		khttp.WithTimeout(0),
+		khttp.WithMiddleware(clientMiddleware(o)...),
+		khttp.WithErrorDecoder(decodeError),
+	}
+	if o.userAgent != "" {
+		kopts = append(kopts, khttp.WithUserAgent(o.userAgent))
+	}
+	if o.tlsConf != nil {
+		kopts = append(kopts, khttp.WithTLSConfig(o.tlsConf))
+	}
+	if o.transport != nil {
+		kopts = append(kopts, khttp.WithTransport(o.transport))
+	}
+	cc, err := khttp.NewClient(ctx, kopts...)
+	if err != nil {
+		return nil, err
+	}
+	return newClient(&httpConn{cc: cc}, o), nil
+}
+
+// get 以查询参数发起 GET 请求。
+func (h *httpConn) get(ctx context.Context, operation, path string, in proto.Message, out any) error {
+	if q := encodeQuery(in); len(q) > 0 {
+		path += "?" + q.Encode()
+	}
+	return h.invoke(ctx, http.MethodGet, operation, path, nil, out)
+}
+
+func (h *httpConn) invoke(ctx context.Context, method, operation, path string, in, out any) error {
+	return h.cc.Invoke(ctx, method, path, in, out, khttp.Operation(operation))
+}
+
+// decodeError kratos 默认错误解码，并将 Retry-After 响应头记入错误。
+func decodeError(ctx context.Context, res *http.Response) error {
+	return withRetryAfter(khttp.DefaultErrorDecoder(ctx, res), res.Header.Get("Retry-After"))
+}
+
+// encodeQuery 按 Nominatim v1 语法编码请求字段：列表与 locales 以逗号连接，布尔为 0/1，
+// viewbox 为 "left,top,right,bottom"，accept_language 使用 accept-language；零值字段省略。
+func encodeQuery(m proto.Message) url.Values {
+	q := url.Values{}
+	m.ProtoReflect().Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		key := string(fd.Name())
+		if key == "accept_language" {
+			key = "accept-language"
+		}
+		switch {
+		case fd.IsMap():
+		case fd.IsList():
+			l := v.List()
+			items := make([]string, 0, l.Len())
+			for i := 0; i < l.Len(); i++ {
+				items = append(items, queryScalar(fd, l.Get(i)))
+			}
+			q.Set(key, strings.Join(items, ","))
+		case fd.Kind() == protoreflect.MessageKind:
+			switch x := v.Message().Interface().(type) {
+			case *v1.ViewBox:
+				q.Set(key, strings.Join([]string{
+					formatFloat(x.Left), formatFloat(x.Top), formatFloat(x.Right), formatFloat(x.Bottom),
+				}, ","))
+			case *v1.Locales:
+				q.Set(key, strings.Join(x.Codes, ","))
+			}
+		default:
+			q.Set(key, queryScalar(fd, v))
+		}
+		return true
+	})
+	return q
+}
+
+func queryScalar(fd protoreflect.FieldDescriptor, v protoreflect.Value) string {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		if v.Bool() {
+			return "1"
+		}
+		return "0"
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return formatFloat(v.Float())
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByNumber(v.Enum()); ev != nil {
+			return strings.ToLower(string(ev.Name()))
+		}
+		return strconv.Itoa(int(v.Enum()))
+	}
+	return v.String()
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func (h *httpConn) Search(ctx context.Context, req *v1.SearchRequest) (*v1.SearchResponse, error) {
+	var out v1.SearchResponse
+	if err := h.get(ctx, v1.OperationNominatimServiceSearch, "/search", req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (h *httpConn) Reverse(ctx context.Context, req *v1.ReverseRequest) (*v1.ReverseResponse, error) {
+	var out v1.ReverseResponse
+	if err := h.get(ctx, v1.OperationNominatimServiceReverse, "/reverse", req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (h *httpConn) Lookup(ctx context.Context, req *v1.LookupRequest) (*v1.LookupResponse, error) {
+	var out v1.LookupResponse
+	if err := h.get(ctx, v1.OperationNominatimServiceLookup, "/lookup", req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (h *httpConn) Details(ctx context.Context, req *v1.DetailsRequest) (*v1.DetailsResponse, error) {
+	var out v1.DetailsResponse
+	if err := h.get(ctx, v1.OperationNominatimServiceDetails, "/details", req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (h *httpConn) Status(ctx context.Context, req *v1.StatusRequest) (*v1.StatusResponse, error) {
+	var out v1.StatusResponse
+	if err := h.get(ctx, v1.OperationNominatimServiceStatus, "/status", req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (h *httpConn) BatchSearch(ctx context.Context, req *v1.BatchSearchRequest) (*v1.BatchSearchResponse, error) {
+	var out v1.BatchSearchResponse
+	if err := h.invoke(ctx, http.MethodPost, v1.OperationNominatimServiceBatchSearch, "/search/batch", req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (h *httpConn) BatchReverse(ctx context.Context, req *v1.BatchReverseRequest) (*v1.BatchReverseResponse, error) {
+	var out v1.BatchReverseResponse
+	if err := h.invoke(ctx, http.MethodPost, v1.OperationNominatimServiceBatchReverse, "/reverse/batch", req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (h *httpConn) Deletable(ctx context.Context, req *emptypb.Empty) (*v1.DeletableResponse, error) {
+	var out v1.DeletableResponse
+	if err := h.get(ctx, v1.OperationNominatimServiceDeletable, "/deletable", req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (h *httpConn) Polygons(ctx context.Context, req *v1.PolygonsRequest) (*v1.PolygonsResponse, error) {
+	var out v1.PolygonsResponse
+	if err := h.get(ctx, v1.OperationNominatimServicePolygons, "/polygons", req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (h *httpConn) Close() error {
+	return h.cc.Close()
+}
@@ -0,0 +1,114 @@
+package client
+
+import (
+	"crypto/tls"
+	"net/http"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/middleware"
+	"google.golang.org/grpc"
+)
+
+// 默认值
+const (
+	defaultTimeout     = 10 * time.Second
+	defaultMaxAttempts = 3
+	defaultBaseBackoff = 200 * time.Millisecond
+	defaultMaxBackoff  = 5 * time.Second
+)
+
+// Option 客户端选项（gRPC 与 HTTP 通用，传输专属的选项在另一种传输上忽略）。
+type Option func(*options)
+
+type options struct {
+	timeout    time.Duration
+	retry      retryPolicy
+	rps        float64
+	burst      int
+	tlsConf    *tls.Config
+	header     map[string]string
+	userAgent  string
+	middleware []middleware.Middleware
+	dialOpts   []grpc.DialOption
+	transport  http.RoundTripper
+}
+
+func newOptions(opts []Option) options {
+	o := options{
+		timeout: defaultTimeout,
+		retry: retryPolicy{
+			maxAttempts: defaultMaxAttempts,
+			base:        defaultBaseBackoff,
+			max:         defaultMaxBackoff,
+		},
+		header: map[string]string{},
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithTimeout 单次逻辑调用（含全部重试）的截止时间，仅在 ctx 未设置截止时间时生效；<=0 表示不设置。默认 10s。
+func WithTimeout(d time.Duration) Option {
+	return func(o *options) { o.timeout = d }
+}
+
+// WithRetry 429/503 的重试策略：最多尝试 maxAttempts 次（含首次，<=1 关闭重试），
+// 退避从 base 开始指数增长至 max（带抖动，max<=0 不设上限）；服务端返回 Retry-After 时以其为准。默认 3 次、200ms、5s。
+func WithRetry(maxAttempts int, base, max time.Duration) Option {
+	return func(o *options) {
+		o.retry = retryPolicy{maxAttempts: maxAttempts, base: base, max: max}
+	}
+}
+
+// WithRateLimit 客户端限流：每秒 rps 个请求，突发 burst（<1 时取 rps）；每次重试同样消耗令牌。rps<=0 不限流（默认）。
+func WithRateLimit(rps float64, burst int) Option {
+	return func(o *options) {
+		o.rps = rps
+		o.burst = burst
+	}
+}
+
+// WithTLSConfig 使用 TLS（mTLS 时在 tls.Config 中提供客户端证书）。
+func WithTLSConfig(c *tls.Config) Option {
+	return func(o *options) { o.tlsConf = c }
+}
+
+// WithToken 以 Authorization: Bearer 携带 token（对应服务端 server.auth）。
+func WithToken(token string) Option {
+	return WithHeader("Authorization", "Bearer "+token)
+}
+
+// WithAPIKey 以 API key 请求头携带凭据（header 为空时使用 X-API-Key）。
+func WithAPIKey(header, key string) Option {
+	if header == "" {
+		header = "X-API-Key"
+	}
+	return WithHeader(header, key)
+}
+
+// WithHeader 为每个请求附加请求头（gRPC 为 metadata）。
+func WithHeader(key, value string) Option {
+	return func(o *options) { o.header[key] = value }
+}
+
+// WithUserAgent 设置 User-Agent（Nominatim 使用规范要求标识应用）。
+func WithUserAgent(ua string) Option {
+	return func(o *options) { o.userAgent = ua }
+}
+
+// WithMiddleware 追加 kratos 客户端中间件（如 tracing、metrics），每次尝试各执行一次。
+func WithMiddleware(m ...middleware.Middleware) Option {
+	return func(o *options) { o.middleware = append(o.middleware, m...) }
+}
+
+// WithDialOptions 追加 gRPC 拨号选项（仅 gRPC）。
+func WithDialOptions(opts ...grpc.DialOption) Option {
+	return func(o *options) { o.dialOpts = append(o.dialOpts, opts...) }
+}
+
+// WithHTTPTransport 指定 HTTP RoundTripper（仅 HTTP）。
+func WithHTTPTransport(rt http.RoundTripper) Option {
+	return func(o *options) { o.transport = rt }
+}
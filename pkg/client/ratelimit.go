@@ -0,0 +1,69 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/errors"
+)
+
+// limiter 客户端令牌桶：按预约方式分配令牌，等待超出截止时间时立即失败并归还令牌。
+type limiter struct {
+	mu       sync.Mutex
+	rate     float64 // tokens per second
+	capacity float64
+	tokens   float64
+	last     time.Time
+}
+
+func newLimiter(rps float64, burst int) *limiter {
+	capacity := float64(burst)
+	if capacity < 1 {
+		capacity = rps
+	}
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &limiter{rate: rps, capacity: capacity, tokens: capacity, last: time.Now()}
+}
+
+// reserve 预约一个令牌，返回需等待的时长（令牌数可为负，表示已被预约的额度）。
+func (l *limiter) reserve(now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.tokens = min(l.capacity, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+func (l *limiter) cancel() {
+	l.mu.Lock()
+	l.tokens = min(l.capacity, l.tokens+1)
+	l.mu.Unlock()
+}
+
+// wait 阻塞至获得令牌；ctx 截止前无法获得时返回错误（不发起请求）。
+func (l *limiter) wait(ctx context.Context) error {
+	d := l.reserve(time.Now())
+	if d <= 0 {
+		return nil
+	}
+	if dl, ok := ctx.Deadline(); ok && time.Until(dl) < d {
+		l.cancel()
+		return errors.New(429, "CLIENT_RATE_LIMIT", "client rate limit: deadline exceeded before a token is available")
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		l.cancel()
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,98 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+)
+
+func TestLimiterReserve(t *testing.T) {
+	start := time.Now()
+	tests := []struct {
+		name  string
+		rps   float64
+		burst int
+		at    []time.Duration // 相对 start 的预约时刻
+		want  []time.Duration // 对应的等待时长
+	}{
+		{
+			name: "burst then paced", rps: 10, burst: 2,
+			at:   []time.Duration{0, 0, 0, 0},
+			want: []time.Duration{0, 0, 100 * time.Millisecond, 200 * time.Millisecond},
+		},
+		{
+			name: "refill over time", rps: 10, burst: 1,
+			at:   []time.Duration{0, 0, 300 * time.Millisecond},
+			want: []time.Duration{0, 100 * time.Millisecond, 0},
+		},
+		{
+			name: "refill capped at burst", rps: 10, burst: 2,
+			at:   []time.Duration{0, 0, time.Second, time.Second, time.Second},
+			want: []time.Duration{0, 0, 0, 0, 100 * time.Millisecond},
+		},
+		{
+			name: "burst defaults to rps", rps: 3, burst: 0,
+			at:   []time.Duration{0, 0, 0, 0},
+			want: []time.Duration{0, 0, 0, time.Second / 3},
+		},
+		{
+			name: "at least one token", rps: 0.5, burst: 0,
+			at:   []time.Duration{0, 0},
+			want: []time.Duration{0, 2 * time.Second},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := newLimiter(tt.rps, tt.burst)
+			l.last = start
+			for i, at := range tt.at {
+				got := l.reserve(start.Add(at))
+				if diff := got - tt.want[i]; diff < -time.Millisecond || diff > time.Millisecond {
+					t.Fatalf("reserve #%d = %v, want %v", i, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestLimiterWait(t *testing.T) {
+	t.Run("deadline before token", func(t *testing.T) {
+		l := newLimiter(1, 1)
+		if err := l.wait(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		err := l.wait(ctx)
+		if se := kerrors.FromError(err); se.GetCode() != 429 || se.GetReason() != "CLIENT_RATE_LIMIT" {
+			t.Fatalf("err = %v, want CLIENT_RATE_LIMIT", err)
+		}
+		// 失败的预约须归还令牌：下一次预约的等待不应累加
+		if d := l.reserve(time.Now()); d > time.Second+10*time.Millisecond {
+			t.Fatalf("reserve after failed wait = %v, want <= 1s", d)
+		}
+	})
+	t.Run("canceled while waiting", func(t *testing.T) {
+		l := newLimiter(5, 1)
+		_ = l.wait(context.Background())
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(20*time.Millisecond, cancel)
+		if err := l.wait(ctx); !errors.Is(err, context.Canceled) {
+			t.Fatalf("err = %v, want context.Canceled", err)
+		}
+	})
+	t.Run("waits for token", func(t *testing.T) {
+		l := newLimiter(20, 1)
+		_ = l.wait(context.Background())
+		start := time.Now()
+		if err := l.wait(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		if d := time.Since(start); d < 40*time.Millisecond {
+			t.Fatalf("waited %v, want about 50ms", d)
+		}
+	})
+}
@@ -0,0 +1,190 @@
+package client
+
+import (
+	"strings"
+
+	v1 "nominatim-go/api/nominatim/v1"
+)
+
+// SearchOption 设置 SearchRequest 的可选参数。
+type SearchOption interface{ applySearch(*v1.SearchRequest) }
+
+// ReverseOption 设置 ReverseRequest 的可选参数。
+type ReverseOption interface{ applyReverse(*v1.ReverseRequest) }
+
+// LookupOption 设置 LookupRequest 的可选参数。
+type LookupOption interface{ applyLookup(*v1.LookupRequest) }
+
+type searchOption func(*v1.SearchRequest)
+
+func (f searchOption) applySearch(r *v1.SearchRequest) { f(r) }
+
+type reverseOption func(*v1.ReverseRequest)
+
+func (f reverseOption) applyReverse(r *v1.ReverseRequest) { f(r) }
+
+// NewSearchRequest 构造搜索请求；结构化查询时 q 留空并使用 Structured。
+func NewSearchRequest(q string, opts ...SearchOption) *v1.SearchRequest {
+	r := &v1.SearchRequest{Q: q}
+	for _, o := range opts {
+		o.applySearch(r)
+	}
+	return r
+}
+
+// NewReverseRequest 构造逆地理请求。
+func NewReverseRequest(lat, lon float64, opts ...ReverseOption) *v1.ReverseRequest {
+	r := &v1.ReverseRequest{Lat: lat, Lon: lon}
+	for _, o := range opts {
+		o.applyReverse(r)
+	}
+	return r
+}
+
+// NewLookupRequest 构造按 OSM ID 查询的请求（如 "N123"、"W456"、"R789"）。
+func NewLookupRequest(osmIDs []string, opts ...LookupOption) *v1.LookupRequest {
+	r := &v1.LookupRequest{OsmIds: osmIDs}
+	for _, o := range opts {
+		o.applyLookup(r)
+	}
+	return r
+}
+
+// 搜索参数
+
+// Limit 最大返回数量（服务端按 nominatim.max_limit 截断）。
+func Limit(n uint32) SearchOption {
+	return searchOption(func(r *v1.SearchRequest) { r.Limit = n })
+}
+
+// Offset 分页偏移。
+func Offset(n uint32) SearchOption {
+	return searchOption(func(r *v1.SearchRequest) { r.Offset = n })
+}
+
+// CountryCodes 国家代码过滤（ISO 3166-1 alpha-2）。
+func CountryCodes(codes ...string) SearchOption {
+	return searchOption(func(r *v1.SearchRequest) { r.Countrycodes = strings.Join(codes, ",") })
+}
+
+// FeatureType 结果类型过滤（country/state/city/settlement）。
+func FeatureType(t string) SearchOption {
+	return searchOption(func(r *v1.SearchRequest) { r.Featuretype = t })
+}
+
+// Dedupe 去除重复结果。
+func Dedupe() SearchOption {
+	return searchOption(func(r *v1.SearchRequest) { r.Dedupe = true })
+}
+
+// ViewBox 优先（bounded 时限定）视窗范围内的结果。
+func ViewBox(left, top, right, bottom float64, bounded bool) SearchOption {
+	return searchOption(func(r *v1.SearchRequest) {
+		r.Viewbox = &v1.ViewBox{Left: left, Top: top, Right: right, Bottom: bottom}
+		r.Bounded = bounded
+	})
+}
+
+// ExcludePlaceIDs 排除指定 place_id（用于翻页）。
+func ExcludePlaceIDs(ids ...int64) SearchOption {
+	return searchOption(func(r *v1.SearchRequest) { r.ExcludePlaceIds = append(r.ExcludePlaceIds, ids...) })
+}
+
+// Address 结构化查询字段（与 q 互斥）。
+type Address struct {
+	Amenity    string
+	Street     string
+	City       string
+	County     string
+	State      string
+	Country    string
+	PostalCode string
+}
+
+// Structured 结构化查询。
+func Structured(a Address) SearchOption {
+	return searchOption(func(r *v1.SearchRequest) {
+		r.Amenity = a.Amenity
+		r.Street = a.Street
+		r.City = a.City
+		r.County = a.County
+		r.State = a.State
+		r.Country = a.Country
+		r.Postalcode = a.PostalCode
+	})
+}
+
+// 逆地理参数
+
+// Zoom 地址详细程度（0-18，3 为国家，18 为建筑）。
+func Zoom(z uint32) ReverseOption {
+	return reverseOption(func(r *v1.ReverseRequest) { r.Zoom = z })
+}
+
+// 搜索与逆地理共有参数
+
+// LayerOption 可用于 Search 与 Reverse 的 layer 过滤。
+type LayerOption []string
+
+func (l LayerOption) applySearch(r *v1.SearchRequest)   { r.Layer = strings.Join(l, ",") }
+func (l LayerOption) applyReverse(r *v1.ReverseRequest) { r.Layer = strings.Join(l, ",") }
+
+// Layer 按图层过滤（address、poi、railway、natural、manmade、postcode）。
+func Layer(layers ...string) LayerOption {
+	return LayerOption(layers)
+}
+
+// 输出参数（Search、Reverse、Lookup 通用）
+
+// outputFields 三类请求共有的输出字段。
+type outputFields struct {
+	addressdetails   *bool
+	acceptLanguage   *string
+	polygonGeoJSON   *bool
+	polygonThreshold *float64
+	extratags        *bool
+	namedetails      *bool
+}
+
+// OutputOption 可用于 Search、Reverse 与 Lookup 的输出参数。
+type OutputOption func(*outputFields)
+
+func (o OutputOption) applySearch(r *v1.SearchRequest) {
+	o(&outputFields{&r.Addressdetails, &r.AcceptLanguage, &r.PolygonGeojson, &r.PolygonThreshold, &r.Extratags, &r.Namedetails})
+}
+
+func (o OutputOption) applyReverse(r *v1.ReverseRequest) {
+	o(&outputFields{&r.Addressdetails, &r.AcceptLanguage, &r.PolygonGeojson, &r.PolygonThreshold, &r.Extratags, &r.Namedetails})
+}
+
+func (o OutputOption) applyLookup(r *v1.LookupRequest) {
+	o(&outputFields{&r.Addressdetails, &r.AcceptLanguage, &r.PolygonGeojson, &r.PolygonThreshold, &r.Extratags, &r.Namedetails})
+}
+
+// AddressDetails 返回地址明细。
+func AddressDetails() OutputOption {
+	return func(f *outputFields) { *f.addressdetails = true }
+}
+
+// AcceptLanguage 结果语言（Accept-Language 语法，如 "zh-TW,en;q=0.8"）。
+func AcceptLanguage(lang string) OutputOption {
+	return func(f *outputFields) { *f.acceptLanguage = lang }
+}
+
+// PolygonGeoJSON 返回 GeoJSON 多边形，threshold>0 时按该阈值简化。
+func PolygonGeoJSON(threshold float64) OutputOption {
+	return func(f *outputFields) {
+		*f.polygonGeoJSON = true
+		*f.polygonThreshold = threshold
+	}
+}
+
+// ExtraTags 返回 extratags。
+func ExtraTags() OutputOption {
+	return func(f *outputFields) { *f.extratags = true }
+}
+
+// NameDetails 返回 namedetails。
+func NameDetails() OutputOption {
+	return func(f *outputFields) { *f.namedetails = true }
+}
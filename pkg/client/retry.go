@@ -0,0 +1,91 @@
+package client
+
+import (
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/errors"
+)
+
+// retryAfterKey 错误 metadata 中记录服务端 Retry-After 的键（HTTP 响应头或 gRPC header）。
+const retryAfterKey = "Retry-After"
+
+// retryPolicy 429/503 的退避重试策略。
+type retryPolicy struct {
+	maxAttempts int
+	base        time.Duration
+	max         time.Duration
+}
+
+// backoff 第 attempt 次失败后的等待时长：base*2^(attempt-1)，上限 max（<=0 不设上限），取 [d/2, d] 的随机抖动。
+func (p retryPolicy) backoff(attempt int) time.Duration {
+	d := p.base
+	for i := 1; i < attempt && (p.max <= 0 || d < p.max) && d < math.MaxInt64/2; i++ {
+		d *= 2
+	}
+	if p.max > 0 && d > p.max {
+		d = p.max
+	}
+	if d <= 0 {
+		return 0
+	}
+	half := d / 2
+	return half + rand.N(d-half+1)
+}
+
+// retryable 是否可重试：限流（429）或服务暂不可用（503，gRPC Unavailable）。
+func retryable(err error) bool {
+	switch errors.FromError(err).GetCode() {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	}
+	return false
+}
+
+// retryAfter 取错误携带的 Retry-After（秒数或 HTTP 日期）。
+func retryAfter(err error) (time.Duration, bool) {
+	se := new(errors.Error)
+	if !errors.As(err, &se) {
+		return 0, false
+	}
+	return parseRetryAfter(se.GetMetadata()[retryAfterKey])
+}
+
+func parseRetryAfter(v string) (time.Duration, bool) {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return 0, false
+	}
+	if s, err := strconv.Atoi(v); err == nil {
+		if s < 0 {
+			return 0, false
+		}
+		return time.Duration(s) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+// withRetryAfter 将服务端返回的 Retry-After 记入错误 metadata；无该值时原样返回错误。
+func withRetryAfter(err error, value string) error {
+	if err == nil || value == "" {
+		return err
+	}
+	se := errors.FromError(err)
+	md := make(map[string]string, len(se.GetMetadata())+1)
+	for k, v := range se.GetMetadata() {
+		md[k] = v
+	}
+	md[retryAfterKey] = value
+	return se.WithMetadata(md).WithCause(err)
+}
@@ -0,0 +1,115 @@
+package client
+
+import (
+	"context"
+	stderrors "errors"
+	"math"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/errors"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		name string
+		v    string
+		want time.Duration
+		ok   bool
+	}{
+		{name: "empty", v: ""},
+		{name: "seconds", v: "3", want: 3 * time.Second, ok: true},
+		{name: "zero with spaces", v: " 0 ", want: 0, ok: true},
+		{name: "negative", v: "-1"},
+		{name: "garbage", v: "soon"},
+		{name: "past date", v: "Wed, 21 Oct 2015 07:28:00 GMT", want: 0, ok: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseRetryAfter(tt.v)
+			if got != tt.want || ok != tt.ok {
+				t.Fatalf("parseRetryAfter(%q) = %v, %v; want %v, %v", tt.v, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+
+	t.Run("future date", func(t *testing.T) {
+		v := time.Now().Add(90 * time.Second).UTC().Format(http.TimeFormat)
+		got, ok := parseRetryAfter(v)
+		if !ok || got < 80*time.Second || got > 90*time.Second {
+			t.Fatalf("parseRetryAfter(%q) = %v, %v", v, got, ok)
+		}
+	})
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	p := retryPolicy{maxAttempts: 5, base: 100 * time.Millisecond, max: time.Second}
+	tests := []struct {
+		name     string
+		p        retryPolicy
+		attempt  int
+		min, max time.Duration
+	}{
+		{name: "first", p: p, attempt: 1, min: 50 * time.Millisecond, max: 100 * time.Millisecond},
+		{name: "second doubles", p: p, attempt: 2, min: 100 * time.Millisecond, max: 200 * time.Millisecond},
+		{name: "third", p: p, attempt: 3, min: 200 * time.Millisecond, max: 400 * time.Millisecond},
+		{name: "capped", p: p, attempt: 5, min: 500 * time.Millisecond, max: time.Second},
+		{name: "stays capped", p: p, attempt: 50, min: 500 * time.Millisecond, max: time.Second},
+		{name: "base above max", p: retryPolicy{base: 2 * time.Second, max: time.Second}, attempt: 1, min: 500 * time.Millisecond, max: time.Second},
+		{name: "no max", p: retryPolicy{base: 10 * time.Millisecond}, attempt: 4, min: 40 * time.Millisecond, max: 80 * time.Millisecond},
+		{name: "no max overflow", p: retryPolicy{base: time.Second}, attempt: 200, min: math.MaxInt64 / 4, max: math.MaxInt64},
+		{name: "zero base", p: retryPolicy{max: time.Second}, attempt: 3, min: 0, max: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// 抖动随机，多次取样
+			for i := 0; i < 100; i++ {
+				if d := tt.p.backoff(tt.attempt); d < tt.min || d > tt.max {
+					t.Fatalf("backoff(%d) = %v, want in [%v, %v]", tt.attempt, d, tt.min, tt.max)
+				}
+			}
+		})
+	}
+}
+
+func TestRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "rate limited", err: errors.New(429, "RATE_LIMIT", ""), want: true},
+		{name: "unavailable", err: errors.ServiceUnavailable("UNAVAILABLE", ""), want: true},
+		{name: "not found", err: errors.NotFound("NOT_FOUND", "")},
+		{name: "bad request", err: errors.BadRequest("BAD_REQUEST", "")},
+		{name: "timeout", err: errors.GatewayTimeout("TIMEOUT", "")},
+		{name: "canceled", err: context.Canceled},
+		{name: "plain", err: stderrors.New("boom")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := retryable(tt.err); got != tt.want {
+				t.Fatalf("retryable(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithRetryAfter(t *testing.T) {
+	base := errors.New(429, "RATE_LIMIT", "slow down").WithMetadata(map[string]string{"k": "v"})
+	err := withRetryAfter(base, "7")
+	if d, ok := retryAfter(err); !ok || d != 7*time.Second {
+		t.Fatalf("retryAfter = %v, %v; want 7s", d, ok)
+	}
+	se := errors.FromError(err)
+	if se.GetCode() != 429 || se.GetMetadata()["k"] != "v" {
+		t.Fatalf("error = %v, want code and metadata kept", se)
+	}
+	if base.GetMetadata()[retryAfterKey] != "" {
+		t.Fatal("original error metadata modified")
+	}
+	if withRetryAfter(base, "") != error(base) {
+		t.Fatal("empty Retry-After should return the error unchanged")
+	}
+}
@@ -0,0 +1,231 @@
+// Package locale 解析语言偏好（Accept-Language）并按 Nominatim 的名称键优先级选择本地化名称。
+package locale
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Locales 按优先级排列的语言标签（已展开脚本/地区回退链，已去重）。
+type Locales []string
+
+// 地区隐含的书写系统（如 zh-TW 使用繁体）
+var regionScripts = map[string]string{
+	"zh-TW": "Hant",
+	"zh-HK": "Hant",
+	"zh-MO": "Hant",
+	"zh-CN": "Hans",
+	"zh-SG": "Hans",
+	"zh-MY": "Hans",
+}
+
+// 书写系统的常见地区标签（OSM 中 name:zh-Hant 与 name:zh-TW 等并存）
+var scriptRegions = map[string][]string{
+	"zh-Hant": {"zh-TW", "zh-HK", "zh-MO"},
+	"zh-Hans": {"zh-CN", "zh-SG"},
+}
+
+type weighted struct {
+	tag string
+	q   float64
+}
+
+// Parse 解析 Accept-Language（如 "zh-TW, en;q=0.8"）：
+// 按 q 值降序稳定排序，忽略 q=0、通配符 * 与无法解析的项；逗号分隔的语言代码列表同样适用（按出现顺序）。
+// 每个标签按 RFC 4647 lookup 逐级回退（zh-Hant-TW → zh-Hant → zh-TW），并补充地区隐含的书写系统（zh-TW → zh-Hant）；
+// 基础语言（zh）仅在未被显式列出时紧随其后追加，避免打乱显式权重。
+func Parse(header string) Locales {
+	var items []weighted
+	for _, part := range strings.Split(header, ",") {
+		tag, q, ok := parseRange(part)
+		if !ok {
+			continue
+		}
+		items = append(items, weighted{tag: tag, q: q})
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].q > items[j].q })
+
+	explicit := make(map[string]struct{}, len(items))
+	for _, it := range items {
+		explicit[strings.ToLower(it.tag)] = struct{}{}
+	}
+	var out Locales
+	seen := map[string]struct{}{}
+	add := func(tag string) {
+		k := strings.ToLower(tag)
+		if _, ok := seen[k]; ok {
+			return
+		}
+		seen[k] = struct{}{}
+		out = append(out, tag)
+	}
+	for _, it := range items {
+		for _, tag := range fallbacks(it.tag) {
+			if _, ok := explicit[strings.ToLower(tag)]; ok && tag != it.tag {
+				continue
+			}
+			add(tag)
+		}
+	}
+	return out
+}
+
+// parseRange 解析单个语言范围及其 q 值；标签按 BCP 47 习惯规范大小写（zh-Hant-TW）。
+func parseRange(s string) (string, float64, bool) {
+	tag, params, _ := strings.Cut(strings.TrimSpace(s), ";")
+	tag = strings.TrimSpace(tag)
+	if tag == "" || tag == "*" {
+		return "", 0, false
+	}
+	q := 1.0
+	if params != "" {
+		k, v, ok := strings.Cut(strings.TrimSpace(params), "=")
+		if !ok || strings.TrimSpace(k) != "q" {
+			return "", 0, false
+		}
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil || f < 0 || f > 1 {
+			return "", 0, false
+		}
+		q = f
+	}
+	if q == 0 {
+		return "", 0, false
+	}
+	tag = canonical(tag)
+	if tag == "" {
+		return "", 0, false
+	}
+	return tag, q, true
+}
+
+// canonical 规范化语言标签：下划线视作连字符；语言小写、书写系统首字母大写、地区大写。
+func canonical(tag string) string {
+	parts := strings.FieldsFunc(tag, func(r rune) bool { return r == '-' || r == '_' })
+	if len(parts) == 0 {
+		return ""
+	}
+	for i, p := range parts {
+		for _, r := range p {
+			if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9') {
+				return ""
+			}
+		}
+		switch {
+		case i == 0:
+			parts[i] = strings.ToLower(p)
+		case len(p) == 4 && isAlpha(p):
+			parts[i] = strings.ToUpper(p[:1]) + strings.ToLower(p[1:])
+		case len(p) == 2 && isAlpha(p), len(p) == 3 && isDigits(p):
+			parts[i] = strings.ToUpper(p)
+		default:
+			parts[i] = strings.ToLower(p)
+		}
+	}
+	return strings.Join(parts, "-")
+}
+
+// fallbacks 单个标签的回退链（含自身与基础语言）。
+func fallbacks(tag string) []string {
+	parts := strings.Split(tag, "-")
+	lang := parts[0]
+	var script, region string
+	for _, p := range parts[1:] {
+		switch {
+		case len(p) == 4 && isAlpha(p) && script == "" && region == "":
+			script = p
+		case (len(p) == 2 && isAlpha(p) || len(p) == 3 && isDigits(p)) && region == "":
+			region = p
+		}
+	}
+	out := []string{tag}
+	// RFC 4647 lookup：逐级去掉末尾子标签
+	for i := len(parts) - 1; i > 1; i-- {
+		out = append(out, strings.Join(parts[:i], "-"))
+	}
+	switch {
+	case script != "" && region != "":
+		out = append(out, lang+"-"+region)
+	case region != "":
+		if s := regionScripts[lang+"-"+region]; s != "" {
+			out = append(out, lang+"-"+s)
+		}
+	case script != "":
+		out = append(out, scriptRegions[lang+"-"+script]...)
+	}
+	if len(parts) > 1 {
+		out = append(out, lang)
+	}
+	return out
+}
+
+func isAlpha(s string) bool {
+	for _, r := range s {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// NameKeys 名称键的优先级（对齐 Nominatim Locales）：
+// name:<lang>…、name、brand、official_name:<lang>…、short_name:<lang>…、official_name、short_name、ref；
+// 每个键之后紧跟关联对象带入的 _place_ 前缀变体。
+func (l Locales) NameKeys() []string {
+	keys := make([]string, 0, 4*len(l)+12)
+	addLang := func(tags ...string) {
+		for _, t := range tags {
+			for _, lang := range l {
+				keys = append(keys, t+":"+lang, "_place_"+t+":"+lang)
+			}
+		}
+	}
+	addPlain := func(tags ...string) {
+		for _, t := range tags {
+			keys = append(keys, t, "_place_"+t)
+		}
+	}
+	addLang("name")
+	addPlain("name", "brand")
+	addLang("official_name", "short_name")
+	addPlain("official_name", "short_name", "ref")
+	return keys
+}
+
+// Name 按名称键优先级选择名称（精确键名优先，其次不区分大小写）；无匹配时返回空串。
+func (l Locales) Name(names map[string]string) string {
+	if len(names) == 0 {
+		return ""
+	}
+	lower := make(map[string]string, len(names))
+	for k, v := range names {
+		if v == "" {
+			continue
+		}
+		lower[strings.ToLower(k)] = v
+	}
+	for _, k := range l.NameKeys() {
+		if v, ok := names[k]; ok && v != "" {
+			return v
+		}
+		if v := lower[strings.ToLower(k)]; v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// String 以逗号拼接，可再次被 Parse 解析。
+func (l Locales) String() string {
+	return strings.Join(l, ",")
+}
@@ -0,0 +1,71 @@
+package locale
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   Locales
+	}{
+		{name: "empty", header: "", want: nil},
+		{name: "single", header: "en", want: Locales{"en"}},
+		{name: "list order", header: "de,en", want: Locales{"de", "en"}},
+		{name: "q sort", header: "en;q=0.5, de", want: Locales{"de", "en"}},
+		{name: "stable equal q", header: "fr;q=0.8, it;q=0.8, en", want: Locales{"en", "fr", "it"}},
+		{name: "region implies script", header: "zh-TW, en;q=0.8", want: Locales{"zh-TW", "zh-Hant", "zh", "en"}},
+		{name: "script expands regions", header: "zh-Hant", want: Locales{"zh-Hant", "zh-TW", "zh-HK", "zh-MO", "zh"}},
+		{name: "lookup chain and canonical case", header: "zh_hant_tw", want: Locales{"zh-Hant-TW", "zh-Hant", "zh-TW", "zh"}},
+		{name: "explicit base keeps weight", header: "en-US, de;q=0.9, en;q=0.8", want: Locales{"en-US", "de", "en"}},
+		{name: "dedupe", header: "en, EN, en-us", want: Locales{"en", "en-US"}},
+		{name: "skip zero and wildcard", header: "fr;q=0, *, en", want: Locales{"en"}},
+		{name: "skip bad q", header: "en;q=abc, de;q=1.5, it", want: Locales{"it"}},
+		{name: "skip unknown param", header: "de;level=1, en", want: Locales{"en"}},
+		{name: "skip bad tag", header: "e n, en", want: Locales{"en"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Parse(tt.header); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Parse(%q) = %v, want %v", tt.header, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocalesName(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		names  map[string]string
+		want   string
+	}{
+		{name: "no names", header: "de", names: nil, want: ""},
+		{name: "language name", header: "de", names: map[string]string{"name": "Munich", "name:de": "München"}, want: "München"},
+		{name: "fallback to name", header: "fr", names: map[string]string{"name": "München", "name:de": "München"}, want: "München"},
+		{name: "second language", header: "fr,de", names: map[string]string{"name": "x", "name:de": "München"}, want: "München"},
+		{name: "script fallback", header: "zh-TW", names: map[string]string{"name": "x", "name:zh-Hant": "慕尼黑"}, want: "慕尼黑"},
+		{name: "base language fallback", header: "zh-TW", names: map[string]string{"name": "x", "name:zh": "慕尼黑"}, want: "慕尼黑"},
+		{name: "case insensitive key", header: "de", names: map[string]string{"name": "x", "Name:DE": "München"}, want: "München"},
+		{name: "place variant before plain name", header: "de", names: map[string]string{"name": "x", "_place_name:de": "München"}, want: "München"},
+		{name: "brand before official name", header: "de", names: map[string]string{"brand": "B", "official_name:de": "O"}, want: "B"},
+		{name: "ref last", header: "de", names: map[string]string{"ref": "A 9", "short_name": "S"}, want: "S"},
+		{name: "empty values skipped", header: "de", names: map[string]string{"name:de": "", "ref": "A 9"}, want: "A 9"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Parse(tt.header).Name(tt.names); got != tt.want {
+				t.Fatalf("Name = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocalesStringRoundTrip(t *testing.T) {
+	l := Parse("de, en-GB;q=0.5")
+	if got := Parse(l.String()); !reflect.DeepEqual(got, l) {
+		t.Fatalf("Parse(String()) = %v, want %v", got, l)
+	}
+}